@@ -0,0 +1,123 @@
+package alerts
+
+import (
+	"strings"
+	"time"
+)
+
+// Sample maps metric names (see Rule.Metric) to their current percentage
+type Sample map[string]float64
+
+// Transition describes a rule starting or stopping to fire
+type Transition struct {
+	Rule   Rule
+	Firing bool
+	Value  float64
+	At     time.Time
+}
+
+// StatusMsg reports the number of active alerts and their highest severity.
+// It is sent to the dashboard whenever an alert changes state.
+type StatusMsg struct {
+	Active int
+	Level  Severity
+}
+
+// ruleState tracks a single rule between samples
+type ruleState struct {
+	pendingSince time.Time
+	active       bool
+}
+
+// Evaluator applies rules to successive samples. A rule fires once its
+// threshold has been breached for the rule's duration and resolves only after
+// the value moves back past the threshold by the rule's hysteresis.
+type Evaluator struct {
+	rules  []Rule
+	states []ruleState
+}
+
+// NewEvaluator creates an evaluator for the given rules
+func NewEvaluator(rules []Rule) *Evaluator {
+	return &Evaluator{
+		rules:  rules,
+		states: make([]ruleState, len(rules)),
+	}
+}
+
+// Evaluate updates rule states from a sample taken at now and returns the
+// resulting transitions. Rules whose metric is missing from the sample keep
+// their current state.
+func (e *Evaluator) Evaluate(sample Sample, now time.Time) []Transition {
+	var transitions []Transition
+	for i, rule := range e.rules {
+		value, ok := sample[rule.Metric]
+		if !ok {
+			continue
+		}
+
+		st := &e.states[i]
+		if st.active {
+			if rule.recovered(value) {
+				st.active = false
+				st.pendingSince = time.Time{}
+				transitions = append(transitions, Transition{Rule: rule, Firing: false, Value: value, At: now})
+			}
+			continue
+		}
+
+		if !rule.breached(value) {
+			st.pendingSince = time.Time{}
+			continue
+		}
+		if st.pendingSince.IsZero() {
+			st.pendingSince = now
+		}
+		if now.Sub(st.pendingSince) >= rule.For {
+			st.active = true
+			transitions = append(transitions, Transition{Rule: rule, Firing: true, Value: value, At: now})
+		}
+	}
+	return transitions
+}
+
+// Level returns the highest severity among active rules for a metric
+func (e *Evaluator) Level(metric string) Severity {
+	level := SeverityNone
+	for i, rule := range e.rules {
+		if e.states[i].active && rule.Metric == metric && rule.Severity > level {
+			level = rule.Severity
+		}
+	}
+	return level
+}
+
+// Status returns the number of active alerts and their highest severity
+func (e *Evaluator) Status() StatusMsg {
+	var status StatusMsg
+	for i, rule := range e.rules {
+		if !e.states[i].active {
+			continue
+		}
+		status.Active++
+		if rule.Severity > status.Level {
+			status.Level = rule.Severity
+		}
+	}
+	return status
+}
+
+// DiskPaths returns the mount points referenced by disk rules
+func (e *Evaluator) DiskPaths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, rule := range e.rules {
+		path, ok := strings.CutPrefix(rule.Metric, MetricDisk+":")
+		if !ok || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	return paths
+}
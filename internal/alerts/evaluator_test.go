@@ -0,0 +1,132 @@
+package alerts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRules(t *testing.T, s string) []Rule {
+	t.Helper()
+	rules, err := ParseRules(s)
+	require.NoError(t, err)
+	return rules
+}
+
+func TestEvaluator(t *testing.T) {
+	start := time.Now()
+	at := func(secs int) time.Time { return start.Add(time.Duration(secs) * time.Second) }
+
+	t.Run("fires after duration", func(t *testing.T) {
+		e := NewEvaluator(mustRules(t, "cpu > 90% for 30s"))
+
+		assert.Empty(t, e.Evaluate(Sample{MetricCPU: 95}, at(0)))
+		assert.Empty(t, e.Evaluate(Sample{MetricCPU: 95}, at(20)))
+
+		transitions := e.Evaluate(Sample{MetricCPU: 96}, at(30))
+		require.Len(t, transitions, 1)
+		assert.True(t, transitions[0].Firing)
+		assert.InDelta(t, 96, transitions[0].Value, 0.001)
+		assert.Equal(t, SeverityWarning, e.Level(MetricCPU))
+		assert.Equal(t, StatusMsg{Active: 1, Level: SeverityWarning}, e.Status())
+	})
+
+	t.Run("dip resets pending duration", func(t *testing.T) {
+		e := NewEvaluator(mustRules(t, "cpu > 90% for 30s"))
+		e.Evaluate(Sample{MetricCPU: 95}, at(0))
+		e.Evaluate(Sample{MetricCPU: 50}, at(10))
+		assert.Empty(t, e.Evaluate(Sample{MetricCPU: 95}, at(30)))
+		assert.Len(t, e.Evaluate(Sample{MetricCPU: 95}, at(60)), 1)
+	})
+
+	t.Run("hysteresis", func(t *testing.T) {
+		e := NewEvaluator(mustRules(t, "critical: swap > 50%"))
+		require.Len(t, e.Evaluate(Sample{MetricSwap: 51}, at(0)), 1)
+		assert.Equal(t, SeverityCritical, e.Level(MetricSwap))
+
+		assert.Empty(t, e.Evaluate(Sample{MetricSwap: 48}, at(2)), "within hysteresis band")
+		assert.Equal(t, SeverityCritical, e.Level(MetricSwap))
+
+		transitions := e.Evaluate(Sample{MetricSwap: 45}, at(4))
+		require.Len(t, transitions, 1)
+		assert.False(t, transitions[0].Firing)
+		assert.Equal(t, SeverityNone, e.Level(MetricSwap))
+		assert.Equal(t, StatusMsg{}, e.Status())
+	})
+
+	t.Run("highest severity wins", func(t *testing.T) {
+		e := NewEvaluator(mustRules(t, "memory > 80%; critical: memory > 95%"))
+		e.Evaluate(Sample{MetricMemory: 97}, at(0))
+		assert.Equal(t, SeverityCritical, e.Level(MetricMemory))
+		assert.Equal(t, StatusMsg{Active: 2, Level: SeverityCritical}, e.Status())
+	})
+
+	t.Run("missing metrics keep state", func(t *testing.T) {
+		e := NewEvaluator(mustRules(t, "swap > 50%"))
+		e.Evaluate(Sample{MetricSwap: 60}, at(0))
+		assert.Empty(t, e.Evaluate(Sample{MetricCPU: 10}, at(2)))
+		assert.Equal(t, SeverityWarning, e.Level(MetricSwap))
+	})
+
+	t.Run("disk paths", func(t *testing.T) {
+		e := NewEvaluator(mustRules(t, "disk / > 85%; disk /home > 90%; critical: disk /home > 95%; cpu > 90%"))
+		assert.Equal(t, []string{"/", "/home"}, e.DiskPaths())
+	})
+}
+
+func TestNotifier(t *testing.T) {
+	rule, err := ParseRule("cpu > 90%")
+	require.NoError(t, err)
+
+	t.Run("bell only when firing", func(t *testing.T) {
+		var out bytes.Buffer
+		n := Notifier{Bell: true, Out: &out}
+		require.NoError(t, n.Notify(Transition{Rule: rule, Firing: false}))
+		assert.Empty(t, out.String())
+		require.NoError(t, n.Notify(Transition{Rule: rule, Firing: true}))
+		assert.Equal(t, bell, out.String())
+	})
+
+	t.Run("hook receives transition", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("hook test uses a POSIX shell")
+		}
+		outFile := filepath.Join(t.TempDir(), "hook.out")
+		n := Notifier{Hook: `echo "$DASHBOARD_ALERT_STATE $DASHBOARD_ALERT_SEVERITY $DASHBOARD_ALERT_VALUE" > ` + outFile}
+		require.NoError(t, n.Notify(Transition{Rule: rule, Firing: true, Value: 93.25}))
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		assert.Equal(t, "firing warning 93.2\n", string(data))
+	})
+
+	t.Run("hook failure", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("hook test uses a POSIX shell")
+		}
+		n := Notifier{Hook: "exit 3"}
+		assert.Error(t, n.Notify(Transition{Rule: rule, Firing: true}))
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv(envAlertRules, "cpu > 90% for 30s")
+	t.Setenv(envAlertBell, "true")
+	t.Setenv(envAlertHook, "notify-send alert")
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Len(t, cfg.Rules, 1)
+	assert.True(t, cfg.Bell)
+	assert.Equal(t, "notify-send alert", cfg.Hook)
+
+	t.Setenv(envAlertRules, "cpu >")
+	_, err = LoadConfig()
+	assert.Error(t, err)
+}
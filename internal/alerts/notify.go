@@ -0,0 +1,103 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// Environment configuration
+const (
+	envAlertRules = "DASHBOARD_ALERTS"
+	envAlertBell  = "DASHBOARD_ALERT_BELL"
+	envAlertHook  = "DASHBOARD_ALERT_HOOK"
+
+	// hookTimeout bounds how long a hook command may run
+	hookTimeout = 10 * time.Second
+	// bell is the terminal bell control character
+	bell = "\a"
+)
+
+// Config holds the alert rules and notification settings
+type Config struct {
+	Rules []Rule
+	// Bell rings the terminal bell when an alert fires
+	Bell bool
+	// Hook is a shell command run on every transition
+	Hook string
+}
+
+// LoadConfig reads alert configuration from the environment:
+// DASHBOARD_ALERTS (semicolon-separated rules), DASHBOARD_ALERT_BELL ("true")
+// and DASHBOARD_ALERT_HOOK (shell command)
+func LoadConfig() (Config, error) {
+	rules, err := ParseRules(os.Getenv(envAlertRules))
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{
+		Rules: rules,
+		Bell:  os.Getenv(envAlertBell) == "true",
+		Hook:  os.Getenv(envAlertHook),
+	}, nil
+}
+
+// Notifier rings the bell and runs the hook command for transitions
+type Notifier struct {
+	Bell bool
+	Hook string
+	// Out receives the bell character; defaults to os.Stdout
+	Out io.Writer
+}
+
+// NewNotifier creates a notifier from the configuration
+func NewNotifier(cfg Config) Notifier {
+	return Notifier{Bell: cfg.Bell, Hook: cfg.Hook, Out: os.Stdout}
+}
+
+// Notify delivers a transition. The hook receives details through the
+// DASHBOARD_ALERT_RULE, DASHBOARD_ALERT_STATE, DASHBOARD_ALERT_SEVERITY and
+// DASHBOARD_ALERT_VALUE environment variables.
+func (n Notifier) Notify(t Transition) error {
+	if n.Bell && t.Firing && n.Out != nil {
+		if _, err := io.WriteString(n.Out, bell); err != nil {
+			return fmt.Errorf("failed to ring bell: %w", err)
+		}
+	}
+
+	if n.Hook == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, n.Hook)
+	state := "resolved"
+	if t.Firing {
+		state = "firing"
+	}
+	cmd.Env = append(os.Environ(),
+		"DASHBOARD_ALERT_RULE="+t.Rule.Expr,
+		"DASHBOARD_ALERT_STATE="+state,
+		"DASHBOARD_ALERT_SEVERITY="+t.Rule.Severity.String(),
+		"DASHBOARD_ALERT_VALUE="+strconv.FormatFloat(t.Value, 'f', 1, 64),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("alert hook failed: %w: %s", err, out)
+	}
+	return nil
+}
+
+// shellCommand runs command through the platform shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
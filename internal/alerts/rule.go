@@ -0,0 +1,186 @@
+// Package alerts evaluates threshold rules against system metric samples
+package alerts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metric names understood by rules
+const (
+	MetricCPU    = "cpu"
+	MetricMemory = "memory"
+	MetricSwap   = "swap"
+	MetricDisk   = "disk"
+
+	// defaultDiskPath is the mount point used by "disk" rules without a path
+	defaultDiskPath = "/"
+	// defaultHysteresis is how many percentage points a value must move back
+	// past the threshold before an active alert resolves
+	defaultHysteresis = 5.0
+)
+
+// Severity is the level of an alert
+type Severity int
+
+// Alert severities in increasing order
+const (
+	SeverityNone Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	}
+	return "none"
+}
+
+// Operator compares a metric value against a threshold
+type Operator string
+
+// Supported operators
+const (
+	OpAbove Operator = ">"
+	OpBelow Operator = "<"
+)
+
+// Rule is a threshold rule such as "cpu > 90% for 30s"
+type Rule struct {
+	// Expr is the rule as written in the configuration
+	Expr string
+	// Metric is the sample key, e.g. "cpu" or "disk:/home"
+	Metric     string
+	Op         Operator
+	Threshold  float64
+	For        time.Duration
+	Severity   Severity
+	Hysteresis float64
+}
+
+// DiskMetric returns the sample key for disk usage of a mount point
+func DiskMetric(path string) string {
+	return MetricDisk + ":" + path
+}
+
+// ParseRule parses a rule of the form
+//
+//	[warning:|critical:] metric [path] (>|<) value[%] [for duration]
+//
+// for example "cpu > 90% for 30s", "disk /home > 85%" or "critical: swap > 50%".
+// Rules without a severity prefix are warnings.
+func ParseRule(expr string) (Rule, error) {
+	rule := Rule{
+		Expr:       strings.TrimSpace(expr),
+		Severity:   SeverityWarning,
+		Hysteresis: defaultHysteresis,
+	}
+
+	body := rule.Expr
+	if prefix, rest, ok := strings.Cut(body, ":"); ok {
+		switch strings.TrimSpace(prefix) {
+		case "warning":
+			body = rest
+		case "critical":
+			rule.Severity = SeverityCritical
+			body = rest
+		}
+	}
+
+	fields := strings.Fields(body)
+	if len(fields) < 3 {
+		return Rule{}, fmt.Errorf("invalid rule %q: expected \"metric > value\"", expr)
+	}
+
+	i := 0
+	switch metric := fields[i]; metric {
+	case MetricCPU, MetricSwap:
+		rule.Metric = metric
+	case MetricMemory, "mem":
+		rule.Metric = MetricMemory
+	case MetricDisk:
+		path := defaultDiskPath
+		if !isOperator(fields[i+1]) {
+			i++
+			path = fields[i]
+		}
+		rule.Metric = DiskMetric(path)
+	default:
+		return Rule{}, fmt.Errorf("invalid rule %q: unknown metric %q", expr, metric)
+	}
+	i++
+
+	if i+1 >= len(fields) || !isOperator(fields[i]) {
+		return Rule{}, fmt.Errorf("invalid rule %q: expected > or < after metric", expr)
+	}
+	rule.Op = Operator(fields[i])
+	i++
+
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(fields[i], "%"), 64)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: bad threshold: %w", expr, err)
+	}
+	rule.Threshold = threshold
+	i++
+
+	if i < len(fields) {
+		if fields[i] != "for" || i+1 >= len(fields) {
+			return Rule{}, fmt.Errorf("invalid rule %q: unexpected %q", expr, fields[i])
+		}
+		d, err := time.ParseDuration(fields[i+1])
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid rule %q: bad duration: %w", expr, err)
+		}
+		rule.For = d
+		i += 2
+	}
+
+	if i != len(fields) {
+		return Rule{}, fmt.Errorf("invalid rule %q: unexpected %q", expr, fields[i])
+	}
+	return rule, nil
+}
+
+// ParseRules parses a semicolon-separated list of rules, skipping empty entries
+func ParseRules(s string) ([]Rule, error) {
+	var rules []Rule
+	for _, expr := range strings.Split(s, ";") {
+		if strings.TrimSpace(expr) == "" {
+			continue
+		}
+		rule, err := ParseRule(expr)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// breached reports whether value violates the rule
+func (r Rule) breached(value float64) bool {
+	if r.Op == OpBelow {
+		return value < r.Threshold
+	}
+	return value > r.Threshold
+}
+
+// recovered reports whether value is far enough back from the threshold to resolve
+func (r Rule) recovered(value float64) bool {
+	if r.Op == OpBelow {
+		return value >= r.Threshold+r.Hysteresis
+	}
+	return value <= r.Threshold-r.Hysteresis
+}
+
+// isOperator reports whether s is a supported operator
+func isOperator(s string) bool {
+	return s == string(OpAbove) || s == string(OpBelow)
+}
@@ -0,0 +1,82 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected Rule
+	}{
+		{
+			name: "cpu with duration",
+			expr: "cpu > 90% for 30s",
+			expected: Rule{Metric: MetricCPU, Op: OpAbove, Threshold: 90, For: 30 * time.Second,
+				Severity: SeverityWarning},
+		},
+		{
+			name:     "disk with path",
+			expr:     "disk /home > 85%",
+			expected: Rule{Metric: "disk:/home", Op: OpAbove, Threshold: 85, Severity: SeverityWarning},
+		},
+		{
+			name:     "disk defaults to root",
+			expr:     "disk > 85",
+			expected: Rule{Metric: "disk:/", Op: OpAbove, Threshold: 85, Severity: SeverityWarning},
+		},
+		{
+			name:     "critical prefix",
+			expr:     "critical: swap > 50%",
+			expected: Rule{Metric: MetricSwap, Op: OpAbove, Threshold: 50, Severity: SeverityCritical},
+		},
+		{
+			name:     "memory alias and below",
+			expr:     "mem < 10%",
+			expected: Rule{Metric: MetricMemory, Op: OpBelow, Threshold: 10, Severity: SeverityWarning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.expr)
+			require.NoError(t, err)
+			tt.expected.Expr = tt.expr
+			tt.expected.Hysteresis = defaultHysteresis
+			assert.Equal(t, tt.expected, rule)
+		})
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"cpu 90",
+		"gpu > 90%",
+		"cpu = 90%",
+		"cpu > ninety",
+		"cpu > 90% for",
+		"cpu > 90% for soon",
+		"cpu > 90% during 30s",
+		"cpu > 90% for 30s extra",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseRule(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("cpu > 90% for 30s; disk / > 85% ;;")
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+
+	_, err = ParseRules("cpu > 90%; bogus")
+	assert.Error(t, err)
+}
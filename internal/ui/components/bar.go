@@ -0,0 +1,32 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// UsageBar renders a horizontal bar of width characters filled to percent
+// (clamped to 0-100) in the given color
+func UsageBar(percent float64, width int, color lipgloss.Color) string {
+	// Ensure valid percentage
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+
+	// Calculate filled and empty portions
+	filled := int(float64(width) * percent / 100)
+	if filled > width {
+		filled = width
+	}
+	empty := width - filled
+
+	// Create the bar with colors
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(styles.Subtle).Render(strings.Repeat("░", empty))
+
+	return bar
+}
@@ -0,0 +1,37 @@
+package components
+
+import (
+	"strings"
+)
+
+// sparkTicks are the block characters used to draw sparklines, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the most recent values as a single-line block graph of at
+// most width characters. Values are scaled relative to the largest value shown.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	maxVal := 0.0
+	for _, v := range values {
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(len(values) * len(string(sparkTicks[0])))
+	for _, v := range values {
+		idx := 0
+		if maxVal > 0 && v > 0 {
+			idx = int(v / maxVal * float64(len(sparkTicks)-1))
+		}
+		b.WriteRune(sparkTicks[idx])
+	}
+	return b.String()
+}
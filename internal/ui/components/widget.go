@@ -32,3 +32,10 @@ type Sizable interface {
 	SetSize(width, height int)
 	GetDimensions() (width, height int)
 }
+
+// InputCapturer is implemented by widgets that temporarily consume all key
+// input, such as while a text prompt is open, so that global shortcuts are
+// not triggered while typing
+type InputCapturer interface {
+	CapturingInput() bool
+}
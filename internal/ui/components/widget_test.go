@@ -1,10 +1,12 @@
 package components
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jonesrussell/dashboard/internal/testutil"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -160,3 +162,20 @@ func TestWidgetSizing(t *testing.T) {
 		})
 	}
 }
+
+func TestSparkline(t *testing.T) {
+	assert.Empty(t, Sparkline(nil, 10))
+	assert.Empty(t, Sparkline([]float64{1, 2}, 0))
+	assert.Equal(t, "▁▁▁", Sparkline([]float64{0, 0, 0}, 10))
+	assert.Equal(t, "▁█", Sparkline([]float64{0, 4}, 10))
+	assert.Equal(t, "▄█", Sparkline([]float64{1, 2, 4}, 2), "keeps most recent values")
+}
+
+func TestUsageBar(t *testing.T) {
+	bar := UsageBar(50, 10, styles.Primary)
+	assert.Equal(t, 5, strings.Count(bar, "█"))
+	assert.Equal(t, 5, strings.Count(bar, "░"))
+
+	assert.Equal(t, 4, strings.Count(UsageBar(-5, 4, styles.Primary), "░"), "clamps below 0")
+	assert.Equal(t, 4, strings.Count(UsageBar(150, 4, styles.Primary), "█"), "clamps above 100")
+}
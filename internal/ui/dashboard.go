@@ -2,17 +2,32 @@
 package ui
 
 import (
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/alerts"
 	"github.com/jonesrussell/dashboard/internal/logger"
 	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
 	"github.com/jonesrussell/dashboard/internal/ui/styles"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/calendar"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/clock"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/docker"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/gitstatus"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/hostinfo"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/logtail"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/network"
 	"github.com/jonesrussell/dashboard/internal/ui/widgets/notes"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/processes"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/sensors"
 	"github.com/jonesrussell/dashboard/internal/ui/widgets/sysinfo"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/systemd"
+	"github.com/jonesrussell/dashboard/internal/ui/widgets/timer"
 )
 
 const (
@@ -22,8 +37,30 @@ const (
 	contentPadding   = 2
 	headerHeight     = 1
 	footerHeight     = 1
+	widgetsPerRow    = 2
+
+	// envDashboardWidgets lists optional widgets to show, e.g. "network"
+	envDashboardWidgets = "DASHBOARD_WIDGETS"
 )
 
+// widgetFactory creates an optional widget
+type widgetFactory func(log logger.Logger) components.Widget
+
+// optionalWidgets maps DASHBOARD_WIDGETS names to their constructors
+var optionalWidgets = map[string]widgetFactory{
+	"calendar":  func(logger.Logger) components.Widget { return calendar.New() },
+	"clock":     func(logger.Logger) components.Widget { return clock.New() },
+	"docker":    func(log logger.Logger) components.Widget { return docker.New(log) },
+	"git":       func(logger.Logger) components.Widget { return gitstatus.New() },
+	"host":      func(logger.Logger) components.Widget { return hostinfo.New() },
+	"logs":      func(logger.Logger) components.Widget { return logtail.New() },
+	"network":   func(logger.Logger) components.Widget { return network.New() },
+	"processes": func(log logger.Logger) components.Widget { return processes.New(log) },
+	"sensors":   func(logger.Logger) components.Widget { return sensors.New() },
+	"systemd":   func(log logger.Logger) components.Widget { return systemd.New(log) },
+	"timer":     func(logger.Logger) components.Widget { return timer.New() },
+}
+
 // Dashboard messages
 type dashboardMsg int
 
@@ -83,10 +120,13 @@ type Dashboard struct {
 	showHelp bool
 	debug    bool
 	logger   logger.Logger
+	focused  int
+	alerts   alerts.StatusMsg
 
 	// Widgets
 	sysInfo components.Widget
 	tasks   components.Widget
+	extras  []components.Widget
 }
 
 // NewDashboard creates a new dashboard instance
@@ -97,23 +137,50 @@ func NewDashboard(log logger.Logger) *Dashboard {
 
 	log.Debug("Initializing dashboard")
 
+	alertCfg, err := alerts.LoadConfig()
+	if err != nil {
+		log.Warn("Ignoring invalid alert rules", logger.NewField("error", err))
+	}
+
 	return &Dashboard{
 		keys:     DefaultKeyMap,
 		help:     help.New(),
 		showHelp: false,
 		debug:    false,
 		logger:   log,
-		sysInfo:  sysinfo.New(),
+		focused:  -1,
+		sysInfo:  sysinfo.New(sysinfo.WithLogger(log), sysinfo.WithAlerts(alertCfg)),
 		tasks:    notes.New(log),
+		extras:   newOptionalWidgets(log, os.Getenv(envDashboardWidgets)),
 	}
 }
 
+// newOptionalWidgets creates the widgets named in a comma-separated list,
+// skipping unknown names
+func newOptionalWidgets(log logger.Logger, names string) []components.Widget {
+	var widgets []components.Widget
+	for _, name := range format.SplitList(names) {
+		factory, ok := optionalWidgets[name]
+		if !ok {
+			log.Warn("Unknown widget", logger.NewField("name", name))
+			continue
+		}
+		log.Debug("Adding optional widget", logger.NewField("name", name))
+		widgets = append(widgets, factory(log))
+	}
+	return widgets
+}
+
 // Init implements tea.Model
 func (d *Dashboard) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		d.sysInfo.Init(),
 		d.tasks.Init(),
-	)
+	}
+	for _, w := range d.extras {
+		cmds = append(cmds, w.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update implements tea.Model
@@ -123,11 +190,18 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case msg.Type == tea.KeyCtrlC:
+			return d, tea.Quit
+		case d.capturingInput():
+			// The focused widget consumes all other keys
 		case key.Matches(msg, d.keys.Quit):
 			return d, tea.Quit
 		case key.Matches(msg, d.keys.Help):
 			d.showHelp = !d.showHelp
 			return d, nil
+		case key.Matches(msg, d.keys.Tab):
+			d.focusNext()
+			return d, nil
 		case msg.String() == "d":
 			d.debug = !d.debug
 			return d, nil
@@ -136,6 +210,10 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		d.width = msg.Width
 		d.height = msg.Height
+
+	case alerts.StatusMsg:
+		d.alerts = msg
+		return d, nil
 	}
 
 	// Update widgets
@@ -147,6 +225,12 @@ func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		d.tasks = tasks.(components.Widget)
 	}
+	for i, w := range d.extras {
+		if extra, cmd := w.Update(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+			d.extras[i] = extra
+		}
+	}
 
 	return d, tea.Batch(cmds...)
 }
@@ -161,6 +245,10 @@ func (d *Dashboard) View() string {
 		header += " Debug: ON"
 	}
 	b.WriteString(styles.Header.Render(header))
+	if badge := d.alertBadge(); badge != "" {
+		b.WriteString(" ")
+		b.WriteString(badge)
+	}
 	b.WriteRune('\n')
 
 	// Main content area with proper padding and minimum sizes
@@ -171,19 +259,7 @@ func (d *Dashboard) View() string {
 		helpContent := "Help\n\n" + d.help.View(d.keys)
 		b.WriteString(styles.WithSize(styles.Base, contentWidth, contentHeight).Render(helpContent))
 	} else {
-		// Layout widgets side by side with equal width distribution
-		widgetWidth := (contentWidth - contentPadding) / 2
-
-		// Set widget sizes before rendering
-		d.sysInfo.SetSize(widgetWidth, contentHeight)
-		d.tasks.SetSize(widgetWidth, contentHeight)
-
-		content := lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			d.sysInfo.View(),
-			d.tasks.View(),
-		)
-		b.WriteString(content)
+		b.WriteString(d.renderWidgets(contentWidth, contentHeight))
 	}
 	b.WriteRune('\n')
 
@@ -192,3 +268,70 @@ func (d *Dashboard) View() string {
 
 	return b.String()
 }
+
+// alertBadge renders the number of active alerts in the severity color
+func (d *Dashboard) alertBadge() string {
+	if d.alerts.Active == 0 {
+		return ""
+	}
+
+	color := styles.Warning
+	if d.alerts.Level == alerts.SeverityCritical {
+		color = styles.Critical
+	}
+	label := "1 alert"
+	if d.alerts.Active > 1 {
+		label = strconv.Itoa(d.alerts.Active) + " alerts"
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(color).Render("⚠ " + label)
+}
+
+// widgets returns all widgets in layout and focus order
+func (d *Dashboard) widgets() []components.Widget {
+	return append([]components.Widget{d.sysInfo, d.tasks}, d.extras...)
+}
+
+// focusNext moves focus to the next widget, wrapping around
+func (d *Dashboard) focusNext() {
+	widgets := d.widgets()
+	if d.focused >= 0 && d.focused < len(widgets) {
+		widgets[d.focused].Blur()
+	}
+	d.focused = (d.focused + 1) % len(widgets)
+	widgets[d.focused].Focus()
+}
+
+// capturingInput reports whether the focused widget is consuming key input
+func (d *Dashboard) capturingInput() bool {
+	widgets := d.widgets()
+	if d.focused < 0 || d.focused >= len(widgets) {
+		return false
+	}
+	capturer, ok := widgets[d.focused].(components.InputCapturer)
+	return ok && capturer.CapturingInput()
+}
+
+// renderWidgets lays out all widgets in rows of widgetsPerRow with equal
+// width distribution and the available height split evenly between rows
+func (d *Dashboard) renderWidgets(contentWidth, contentHeight int) string {
+	widgets := d.widgets()
+
+	rowCount := (len(widgets) + widgetsPerRow - 1) / widgetsPerRow
+	widgetWidth := (contentWidth - contentPadding) / widgetsPerRow
+	widgetHeight := contentHeight / rowCount
+
+	rows := make([]string, 0, rowCount)
+	for start := 0; start < len(widgets); start += widgetsPerRow {
+		end := min(start+widgetsPerRow, len(widgets))
+
+		views := make([]string, 0, widgetsPerRow)
+		for _, w := range widgets[start:end] {
+			// Set widget sizes before rendering
+			w.SetSize(widgetWidth, widgetHeight)
+			views = append(views, w.View())
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, views...))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
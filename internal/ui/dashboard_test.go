@@ -3,6 +3,9 @@ package ui
 import (
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jonesrussell/dashboard/internal/alerts"
+
 	"github.com/jonesrussell/dashboard/internal/testutil/testlogger"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,3 +21,68 @@ func TestDashboard(t *testing.T) {
 	assert.NotNil(t, dash.tasks)
 	assert.NotNil(t, dash.logger)
 }
+
+func TestDashboardOptionalWidgets(t *testing.T) {
+	logger, _ := testlogger.NewTestLogger(t, "dashboard-optional")
+
+	t.Run("none by default", func(t *testing.T) {
+		t.Setenv(envDashboardWidgets, "")
+		dash := NewDashboard(logger)
+		assert.Empty(t, dash.extras)
+	})
+
+	t.Run("configured widgets", func(t *testing.T) {
+		t.Setenv(envDashboardWidgets, "network, unknown")
+		dash := NewDashboard(logger)
+		assert.Len(t, dash.extras, 1)
+
+		dash.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		assert.Contains(t, dash.View(), "Network")
+	})
+}
+
+func TestDashboardFocus(t *testing.T) {
+	logger, _ := testlogger.NewTestLogger(t, "dashboard-focus")
+	t.Setenv(envDashboardWidgets, "processes")
+	dash := NewDashboard(logger)
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	dash.Update(tab)
+	assert.True(t, dash.sysInfo.IsFocused())
+
+	dash.Update(tab)
+	assert.False(t, dash.sysInfo.IsFocused())
+	assert.True(t, dash.tasks.IsFocused())
+
+	dash.Update(tab)
+	assert.True(t, dash.extras[0].IsFocused())
+
+	t.Run("focused widget captures input", func(t *testing.T) {
+		dash.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+		assert.True(t, dash.capturingInput())
+
+		_, cmd := dash.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+		if cmd != nil {
+			_, isQuit := cmd().(tea.QuitMsg)
+			assert.False(t, isQuit, "q is typed into the filter")
+		}
+		dash.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+		assert.False(t, dash.debug)
+	})
+
+	dash.Update(tab)
+	assert.True(t, dash.extras[0].IsFocused(), "tab is captured while filtering")
+}
+
+func TestDashboardAlertBadge(t *testing.T) {
+	logger, _ := testlogger.NewTestLogger(t, "dashboard-alerts")
+	dash := NewDashboard(logger)
+	dash.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	assert.NotContains(t, dash.View(), "alert")
+
+	dash.Update(alerts.StatusMsg{Active: 2, Level: alerts.SeverityCritical})
+	assert.Contains(t, dash.View(), "⚠ 2 alerts")
+
+	dash.Update(alerts.StatusMsg{})
+	assert.NotContains(t, dash.View(), "⚠")
+}
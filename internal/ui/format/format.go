@@ -0,0 +1,74 @@
+// Package format provides human-readable formatting helpers for widget values
+// and parsing helpers for their configuration
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unit is the IEC base used for byte formatting
+const unit = 1024
+
+// byteSuffixes lists the IEC suffixes in increasing order of magnitude
+var byteSuffixes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// Bytes formats a byte count using IEC units (KiB, MiB, ...)
+func Bytes(b uint64) string {
+	return scaled(float64(b))
+}
+
+// Rate formats a bytes-per-second value using IEC units (KiB/s, MiB/s, ...)
+func Rate(bytesPerSec float64) string {
+	return scaled(bytesPerSec) + "/s"
+}
+
+// scaled formats a byte value with the largest suffix that keeps it below 1024
+func scaled(v float64) string {
+	if v < 0 {
+		v = 0
+	}
+	if v < unit {
+		return fmt.Sprintf("%.0f %s", v, byteSuffixes[0])
+	}
+
+	i := 0
+	for v >= unit && i < len(byteSuffixes)-1 {
+		v /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", v, byteSuffixes[i])
+}
+
+// CounterDelta returns the increase of a monotonic counter between two
+// samples, treating a reset or wrap as zero
+func CounterDelta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// Truncate shortens s to at most n runes, marking a cut with an ellipsis
+func Truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 0 {
+		return ""
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// SplitList splits a comma-separated list such as an environment variable,
+// trimming whitespace and dropping empty items
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
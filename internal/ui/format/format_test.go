@@ -0,0 +1,55 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    uint64
+		expected string
+	}{
+		{"zero", 0, "0 B"},
+		{"bytes", 512, "512 B"},
+		{"kibibytes", 1536, "1.5 KiB"},
+		{"mebibytes", 5 * 1024 * 1024, "5.0 MiB"},
+		{"gibibytes", 3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Bytes(tt.input))
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	assert.Equal(t, "0 B/s", Rate(0))
+	assert.Equal(t, "0 B/s", Rate(-10))
+	assert.Equal(t, "2.0 KiB/s", Rate(2048))
+	assert.Equal(t, "1.2 MiB/s", Rate(1.25*1024*1024))
+}
+
+func TestCounterDelta(t *testing.T) {
+	assert.Equal(t, uint64(0), CounterDelta(5, 5))
+	assert.Equal(t, uint64(1024), CounterDelta(1024, 2048))
+	assert.Equal(t, uint64(0), CounterDelta(2048, 10), "counter reset")
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "web", Truncate("web", 10))
+	assert.Equal(t, "postgres", Truncate("postgres", 8))
+	assert.Equal(t, "postg…", Truncate("postgres", 6))
+	assert.Equal(t, "héll…", Truncate("héllo wörld", 5), "counts runes")
+	assert.Equal(t, "", Truncate("web", 0))
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Nil(t, SplitList(""))
+	assert.Nil(t, SplitList(" , ,"))
+	assert.Equal(t, []string{"eth0"}, SplitList("eth0"))
+	assert.Equal(t, []string{"a.ics", "cal/", "b c"}, SplitList(" a.ics,cal/ ,, b c "))
+}
@@ -15,6 +15,10 @@ var (
 	Secondary = lipgloss.Color("#FFB74D")
 	// Subtle is used for less prominent elements
 	Subtle = lipgloss.Color("#4A4A4A")
+	// Warning marks values that crossed a warning threshold
+	Warning = lipgloss.Color("#FFC107")
+	// Critical marks values that crossed a critical threshold
+	Critical = lipgloss.Color("#F44336")
 )
 
 // Pre-defined styles for common use cases
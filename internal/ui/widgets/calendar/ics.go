@@ -0,0 +1,382 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOccurrences bounds recurrence expansion so a bad rule cannot loop forever
+const maxOccurrences = 10000
+
+// Event is a VEVENT from an iCalendar file
+type Event struct {
+	Summary  string
+	Location string
+	Start    time.Time
+	End      time.Time
+	AllDay   bool
+	Rule     *Rule
+	// Exceptions are recurrence start times excluded by EXDATE
+	Exceptions []time.Time
+}
+
+// Occurrence is a single instance of an event
+type Occurrence struct {
+	Event *Event
+	Start time.Time
+	End   time.Time
+}
+
+// Frequency is the RRULE FREQ value
+type Frequency string
+
+// Supported recurrence frequencies
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// Rule is the supported subset of an RRULE: FREQ, INTERVAL, COUNT, UNTIL and
+// BYDAY for weekly rules
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []time.Weekday
+}
+
+// weekdays maps iCalendar day codes to weekdays
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseICS reads the VEVENTs of an iCalendar stream. Times without a zone are
+// interpreted in loc. Unsupported properties and components are ignored;
+// events that cannot be read, such as ones with unsupported recurrence
+// rules, are skipped and a warning says why.
+func ParseICS(r io.Reader, loc *time.Location) (events []Event, warnings []string, err error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cur *Event
+	// nested counts components inside the event, such as VALARM, whose
+	// properties must not override the event's own
+	nested := 0
+	// invalid is the first problem with the current event
+	var invalid error
+	for i, line := range lines {
+		name, params, value, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			cur = &Event{}
+			nested = 0
+			invalid = nil
+			continue
+		case name == "END" && value == "VEVENT":
+			if cur != nil && invalid == nil && cur.Start.IsZero() {
+				invalid = fmt.Errorf("line %d: no DTSTART", i+1)
+			}
+			switch {
+			case cur == nil:
+			case invalid != nil:
+				warnings = append(warnings, fmt.Sprintf("skipped event %q: %v", cur.Summary, invalid))
+			default:
+				finishEvent(cur)
+				events = append(events, *cur)
+			}
+			cur = nil
+			continue
+		case cur == nil:
+			continue
+		case name == "BEGIN":
+			nested++
+			continue
+		case name == "END":
+			nested--
+			continue
+		case nested > 0:
+			continue
+		}
+
+		switch name {
+		case "SUMMARY":
+			cur.Summary = unescape(value)
+		case "LOCATION":
+			cur.Location = unescape(value)
+		case "DTSTART":
+			cur.Start, cur.AllDay, err = parseTime(params, value, loc)
+		case "DTEND":
+			cur.End, _, err = parseTime(params, value, loc)
+		case "RRULE":
+			cur.Rule, err = parseRule(value, loc)
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				var t time.Time
+				if t, _, err = parseTime(params, v, loc); err != nil {
+					break
+				}
+				cur.Exceptions = append(cur.Exceptions, t)
+			}
+		}
+		if err != nil && invalid == nil {
+			// The summary may still follow, so the warning waits for END
+			invalid = fmt.Errorf("line %d: %s: %w", i+1, name, err)
+		}
+		err = nil
+	}
+	return events, warnings, nil
+}
+
+// finishEvent fills in defaults once all properties are known
+func finishEvent(e *Event) {
+	if !e.End.After(e.Start) {
+		if e.AllDay {
+			e.End = e.Start.AddDate(0, 0, 1)
+		} else {
+			e.End = e.Start
+		}
+	}
+}
+
+// Occurrences returns the instances of the event that overlap [from, to)
+func (e *Event) Occurrences(from, to time.Time) []Occurrence {
+	duration := e.End.Sub(e.Start)
+	var out []Occurrence
+
+	add := func(start time.Time) {
+		end := start.Add(duration)
+		if e.AllDay {
+			// Whole days keep their length across DST changes
+			end = start.AddDate(0, 0, int(duration.Round(24*time.Hour)/(24*time.Hour)))
+		}
+		overlaps := start.Before(to) && (end.After(from) || (duration == 0 && !start.Before(from)))
+		if overlaps && !e.excluded(start) {
+			out = append(out, Occurrence{Event: e, Start: start, End: end})
+		}
+	}
+
+	if e.Rule == nil {
+		add(e.Start)
+		return out
+	}
+
+	// Without COUNT, occurrences that end before from need not be generated
+	skipTo := time.Time{}
+	if e.Rule.Count == 0 {
+		skipTo = from.Add(-duration)
+	}
+	count := 0
+	e.Rule.each(e.Start, skipTo, func(start time.Time) bool {
+		if !start.Before(to) || (!e.Rule.Until.IsZero() && start.After(e.Rule.Until)) {
+			return false
+		}
+		count++
+		if e.Rule.Count > 0 && count > e.Rule.Count {
+			return false
+		}
+		add(start)
+		return true
+	})
+	return out
+}
+
+// excluded reports whether start is listed in EXDATE
+func (e *Event) excluded(start time.Time) bool {
+	for _, ex := range e.Exceptions {
+		if ex.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// each calls yield with occurrence starts in order until it returns false
+// or maxOccurrences candidates were generated. Callers stop at their window,
+// COUNT or UNTIL. Whole intervals before skipTo are fast-forwarded, so long
+// running series still reach the displayed window.
+func (r *Rule) each(first, skipTo time.Time, yield func(time.Time) bool) {
+	interval := max(r.Interval, 1)
+
+	start := r.skip(first, skipTo) / interval
+	for n := start; n < start+maxOccurrences; n++ {
+		switch r.Freq {
+		case Daily:
+			if !yield(first.AddDate(0, 0, n*interval)) {
+				return
+			}
+		case Weekly:
+			if len(r.ByDay) == 0 {
+				if !yield(first.AddDate(0, 0, 7*n*interval)) {
+					return
+				}
+				continue
+			}
+			// Days of the n-th recurring week, starting from the week of first
+			weekStart := first.AddDate(0, 0, -int(first.Weekday())+7*n*interval)
+			for _, day := range r.ByDay {
+				t := weekStart.AddDate(0, 0, int(day))
+				if !t.Before(first) && !yield(t) {
+					return
+				}
+			}
+		case Monthly:
+			// Months without the start day (e.g. the 31st) are skipped
+			if t := first.AddDate(0, n*interval, 0); t.Day() == first.Day() && !yield(t) {
+				return
+			}
+		case Yearly:
+			if t := first.AddDate(n*interval, 0, 0); t.Day() == first.Day() && !yield(t) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// skip returns how many whole periods of the rule's frequency lie between
+// first and skipTo, less one to allow for DST and month length differences
+func (r *Rule) skip(first, skipTo time.Time) int {
+	if !skipTo.After(first) {
+		return 0
+	}
+	var periods int
+	switch r.Freq {
+	case Daily:
+		periods = int(skipTo.Sub(first) / (24 * time.Hour))
+	case Weekly:
+		periods = int(skipTo.Sub(first) / (7 * 24 * time.Hour))
+	case Monthly:
+		periods = (skipTo.Year()-first.Year())*12 + int(skipTo.Month()-first.Month())
+	case Yearly:
+		periods = skipTo.Year() - first.Year()
+	}
+	return max(periods-1, 0)
+}
+
+// parseRule parses the supported RRULE parts, rejecting those that would
+// change which dates occur
+func parseRule(value string, loc *time.Location) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(val)
+			switch rule.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			rule.Until, _, err = parseTime(nil, val, loc)
+			if err == nil && len(val) == len("20060102") {
+				// A date UNTIL includes the whole day
+				rule.Until = rule.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdays[code]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY %q", code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+			sort.Slice(rule.ByDay, func(i, j int) bool { return rule.ByDay[i] < rule.ByDay[j] })
+		case "WKST":
+		default:
+			return nil, fmt.Errorf("unsupported %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("missing FREQ")
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != Weekly {
+		return nil, fmt.Errorf("BYDAY is only supported for weekly rules")
+	}
+	return rule, nil
+}
+
+// parseTime parses a DATE or DATE-TIME value. UTC values end in Z; TZID
+// selects a zone; other times are floating and use loc.
+func parseTime(params map[string]string, value string, loc *time.Location) (time.Time, bool, error) {
+	if tzid, ok := params["TZID"]; ok {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == len("20060102"):
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// unfold joins continuation lines, which start with a space or tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty splits NAME;PARAM=VALUE:value into its parts
+func parseProperty(line string) (name string, params map[string]string, value string, ok bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+	parts := strings.Split(head, ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+// unescape decodes iCalendar TEXT escapes
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,413 @@
+// Package calendar provides a widget for a month view and an agenda from iCalendar files
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	// defaultWatchInterval is how often files are checked for changes; it also
+	// moves the today marker at midnight
+	defaultWatchInterval = 5 * time.Second
+	defaultAgendaDays    = 14
+	envFiles             = "DASHBOARD_CALENDAR_FILES"
+	// monthHeight is the number of lines used by the month grid, including
+	// its title and weekday header
+	monthHeight = 8
+	// chromeHeight is the number of lines used by borders, padding, titles
+	// and help text around the month grid and agenda
+	chromeHeight = 8
+)
+
+// Widget represents the calendar widget
+type Widget struct {
+	components.BaseWidget
+	paths         []string
+	watchInterval time.Duration
+	agendaDays    int
+	now           func() time.Time
+
+	events      []Event
+	fingerprint string
+	today       time.Time
+	month       time.Time
+	lastError   error
+	// warnings describe events skipped from otherwise readable files
+	warnings []string
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithFiles sets the .ics files or directories containing them
+func WithFiles(paths ...string) Option {
+	return func(w *Widget) {
+		w.paths = paths
+	}
+}
+
+// WithWatchInterval sets how often files are checked for changes
+func WithWatchInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.watchInterval = interval
+	}
+}
+
+// WithAgendaDays sets how many days ahead the agenda lists events
+func WithAgendaDays(days int) Option {
+	return func(w *Widget) {
+		w.agendaDays = days
+	}
+}
+
+// WithNow sets the time source, mainly for tests
+func WithNow(now func() time.Time) Option {
+	return func(w *Widget) {
+		w.now = now
+	}
+}
+
+// New creates a new calendar widget. Files default to the comma-separated
+// DASHBOARD_CALENDAR_FILES, where directories contribute their *.ics files.
+func New(opts ...Option) *Widget {
+	w := &Widget{
+		paths:         format.SplitList(os.Getenv(envFiles)),
+		watchInterval: defaultWatchInterval,
+		agendaDays:    defaultAgendaDays,
+		now:           time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.setToday(w.now())
+	w.month = firstOfMonth(w.today)
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return tea.Batch(w.loadEvents, w.watchTick())
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		switch msg.String() {
+		case "[":
+			w.month = w.month.AddDate(0, -1, 0)
+		case "]":
+			w.month = w.month.AddDate(0, 1, 0)
+		case ".":
+			w.month = firstOfMonth(w.today)
+		}
+	case eventsMsg:
+		w.lastError = msg.err
+		w.warnings = msg.warnings
+		w.events = msg.events
+		w.fingerprint = msg.fingerprint
+	case watchMsg:
+		if w.setToday(msg.now) && w.month.Equal(firstOfMonth(w.today.AddDate(0, 0, -1))) {
+			// Follow the current month across midnight unless browsing
+			w.month = firstOfMonth(w.today)
+		}
+		if msg.fingerprint != w.fingerprint {
+			return w, tea.Batch(w.loadEvents, w.watchTick())
+		}
+		return w, w.watchTick()
+	}
+	return w, nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	b.WriteString(w.renderMonth())
+	b.WriteString("\n")
+
+	b.WriteString(styles.Title.Render("Agenda"))
+	b.WriteString("\n")
+	switch {
+	case len(w.paths) == 0:
+		b.WriteString(subtle.Render("No calendars configured, set " + envFiles))
+		b.WriteString("\n")
+	default:
+		agenda := w.agenda()
+		if len(agenda) == 0 {
+			b.WriteString(subtle.Render(fmt.Sprintf("No events in the next %d days", w.agendaDays)))
+			b.WriteString("\n")
+		}
+		rows := max(height-monthHeight-chromeHeight, 1)
+		for i, occ := range agenda {
+			if i == rows {
+				b.WriteString(subtle.Render(fmt.Sprintf("+%d more", len(agenda)-rows)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString(w.renderOccurrence(occ))
+			b.WriteString("\n")
+		}
+	}
+
+	if w.lastError != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+		b.WriteString("\n")
+	}
+	if len(w.warnings) > 0 {
+		warning := w.warnings[0]
+		if len(w.warnings) > 1 {
+			warning += fmt.Sprintf(" (+%d more)", len(w.warnings)-1)
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Warning).Render(warning))
+		b.WriteString("\n")
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("[/]: month • .: today"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderMonth renders the displayed month as a Monday-first grid with today
+// highlighted and days with events emphasized
+func (w *Widget) renderMonth() string {
+	var b strings.Builder
+
+	b.WriteString(styles.Title.Render(w.month.Format("January 2006")))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render("Mo Tu We Th Fr Sa Su"))
+	b.WriteString("\n")
+
+	next := w.month.AddDate(0, 1, 0)
+	busy := busyDays(w.occurrences(w.month, next), w.month)
+
+	todayStyle := lipgloss.NewStyle().Reverse(true).Bold(true)
+	busyStyle := lipgloss.NewStyle().Foreground(styles.Secondary).Bold(true)
+
+	// Monday-first column of the 1st
+	col := (int(w.month.Weekday()) + 6) % 7
+	b.WriteString(strings.Repeat("   ", col))
+	for d := w.month; d.Before(next); d = d.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", d.Day())
+		switch {
+		case d.Equal(w.today):
+			cell = todayStyle.Render(cell)
+		case busy[d.Day()]:
+			cell = busyStyle.Render(cell)
+		}
+		b.WriteString(cell)
+
+		col++
+		if col == 7 {
+			col = 0
+			b.WriteString("\n")
+		} else if d.AddDate(0, 0, 1).Before(next) {
+			b.WriteString(" ")
+		}
+	}
+	if col != 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderOccurrence formats an agenda line
+func (w *Widget) renderOccurrence(occ Occurrence) string {
+	day := occ.Start.Format("Mon 02")
+	if sameDay(occ.Start, w.today) {
+		day = "Today "
+	} else if sameDay(occ.Start, w.today.AddDate(0, 0, 1)) {
+		day = "Tmrw  "
+	}
+
+	when := occ.Start.Format("15:04")
+	if occ.Event.AllDay {
+		when = "all day"
+	}
+	line := fmt.Sprintf("%s %-7s %s", lipgloss.NewStyle().Foreground(styles.Subtle).Render(day), when, occ.Event.Summary)
+	if occ.Event.Location != "" {
+		line += lipgloss.NewStyle().Foreground(styles.Subtle).Render(" @ " + occ.Event.Location)
+	}
+	return line
+}
+
+// agenda returns occurrences that have not ended, from today through agendaDays
+func (w *Widget) agenda() []Occurrence {
+	now := w.now()
+	var upcoming []Occurrence
+	for _, occ := range w.occurrences(w.today, w.today.AddDate(0, 0, w.agendaDays)) {
+		if occ.End.After(now) || occ.Start.Equal(occ.End) && !occ.Start.Before(now) {
+			upcoming = append(upcoming, occ)
+		}
+	}
+	return upcoming
+}
+
+// occurrences returns all event instances overlapping [from, to) in start
+// order, in the local time zone
+func (w *Widget) occurrences(from, to time.Time) []Occurrence {
+	var out []Occurrence
+	for i := range w.events {
+		for _, occ := range w.events[i].Occurrences(from, to) {
+			occ.Start = occ.Start.In(w.today.Location())
+			occ.End = occ.End.In(w.today.Location())
+			out = append(out, occ)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Start.Equal(out[j].Start) {
+			return out[i].Start.Before(out[j].Start)
+		}
+		// All-day events first on the same day
+		return out[i].Event.AllDay && !out[j].Event.AllDay
+	})
+	return out
+}
+
+// setToday records the local date of now, reporting whether it changed
+func (w *Widget) setToday(now time.Time) bool {
+	today := startOfDay(now)
+	changed := !today.Equal(w.today)
+	w.today = today
+	return changed
+}
+
+// busyDays returns the days of month on which any of occs takes place
+func busyDays(occs []Occurrence, month time.Time) map[int]bool {
+	busy := make(map[int]bool)
+	for _, occ := range occs {
+		// An event ending at midnight does not occupy the next day
+		last := occ.Start
+		if occ.End.After(occ.Start) {
+			last = occ.End.Add(-time.Nanosecond)
+		}
+		for d := startOfDay(occ.Start); !d.After(last); d = d.AddDate(0, 0, 1) {
+			if d.Year() == month.Year() && d.Month() == month.Month() {
+				busy[d.Day()] = true
+			}
+		}
+	}
+	return busy
+}
+
+// startOfDay returns midnight on t's date
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// firstOfMonth returns midnight on the first day of t's month
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// sameDay reports whether a and b fall on the same calendar date
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// resolveFiles expands directories into the .ics files they contain
+func resolveFiles(paths []string) []string {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil || !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(p, "*.ics"))
+		files = append(files, matches...)
+	}
+	return files
+}
+
+// fingerprint summarizes the configured paths and calendar files so changes
+// can be detected without parsing them
+func fingerprint(paths []string) string {
+	var b strings.Builder
+	for _, p := range append(append([]string(nil), paths...), resolveFiles(paths)...) {
+		info, err := os.Stat(p)
+		if err != nil {
+			b.WriteString("-;")
+			continue
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", p, info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String()
+}
+
+// Message types for the calendar widget
+type eventsMsg struct {
+	events      []Event
+	fingerprint string
+	err         error
+	warnings    []string
+}
+
+type watchMsg struct {
+	now         time.Time
+	fingerprint string
+}
+
+// Commands
+func (w *Widget) watchTick() tea.Cmd {
+	paths, now := w.paths, w.now
+	return tea.Tick(w.watchInterval, func(time.Time) tea.Msg {
+		return watchMsg{now: now(), fingerprint: fingerprint(paths)}
+	})
+}
+
+func (w *Widget) loadEvents() tea.Msg {
+	msg := eventsMsg{fingerprint: fingerprint(w.paths)}
+
+	// A broken file is reported without hiding events from the others
+	var errs []error
+	for _, path := range resolveFiles(w.paths) {
+		f, err := os.Open(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open calendar: %w", err))
+			continue
+		}
+		events, warnings, err := ParseICS(f, time.Local)
+		f.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+			continue
+		}
+		for _, warning := range warnings {
+			msg.warnings = append(msg.warnings, filepath.Base(path)+": "+warning)
+		}
+		msg.events = append(msg.events, events...)
+	}
+	msg.err = errors.Join(errs...)
+	return msg
+}
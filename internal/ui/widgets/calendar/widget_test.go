@@ -0,0 +1,217 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Team standup\r\n" +
+	"LOCATION:Room 1\\, 2nd floor\r\n" +
+	"DTSTART:20240304T093000\r\n" +
+	"DTEND:20240304T094500\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5\r\n" +
+	"EXDATE:20240306T093000\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"SUMMARY:Alarm summary must be ignored\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Public holiday with a long name that is folded\r\n" +
+	"  across lines\r\n" +
+	"DTSTART;VALUE=DATE:20240308\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Tokyo sync\r\n" +
+	"DTSTART;TZID=Asia/Tokyo:20240307T090000\r\n" +
+	"DTEND;TZID=Asia/Tokyo:20240307T100000\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	events, _, err := ParseICS(strings.NewReader(sampleICS), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	standup := events[0]
+	assert.Equal(t, "Team standup", standup.Summary)
+	assert.Equal(t, "Room 1, 2nd floor", standup.Location)
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC), standup.Start)
+	assert.Equal(t, 15*time.Minute, standup.End.Sub(standup.Start))
+	require.NotNil(t, standup.Rule)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Wednesday, time.Friday}, standup.Rule.ByDay)
+
+	holiday := events[1]
+	assert.Equal(t, "Public holiday with a long name that is folded across lines", holiday.Summary)
+	assert.True(t, holiday.AllDay)
+	assert.Equal(t, 24*time.Hour, holiday.End.Sub(holiday.Start))
+
+	assert.Equal(t, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), events[2].Start.UTC())
+
+	t.Run("invalid events are skipped", func(t *testing.T) {
+		event := func(summary, props string) string {
+			return "BEGIN:VEVENT\nSUMMARY:" + summary + "\n" + props + "END:VEVENT\n"
+		}
+		ics := event("no start", "") +
+			event("hourly", "DTSTART:20240101\nRRULE:FREQ=HOURLY\n") +
+			event("second tuesday", "DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYDAY=2TU\n") +
+			event("last friday", "DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYDAY=-1FR\n") +
+			event("monthly mondays", "DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYDAY=MO\n") +
+			event("by month day", "DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1,15\n") +
+			event("fine", "DTSTART:20240101\nRRULE:FREQ=WEEKLY;WKST=MO;BYDAY=MO\n")
+		events, warnings, err := ParseICS(strings.NewReader(ics), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "fine", events[0].Summary)
+		require.Len(t, warnings, 6)
+		assert.Contains(t, warnings[0], `"no start"`)
+		assert.Contains(t, warnings[0], "no DTSTART")
+		assert.Contains(t, warnings[1], "unsupported frequency")
+		assert.Contains(t, warnings[2], `unsupported BYDAY "2TU"`)
+		assert.Contains(t, warnings[3], `unsupported BYDAY "-1FR"`)
+		assert.Contains(t, warnings[4], "only supported for weekly rules")
+		assert.Contains(t, warnings[5], "unsupported BYMONTHDAY")
+	})
+}
+
+func TestOccurrences(t *testing.T) {
+	events, _, err := ParseICS(strings.NewReader(sampleICS), time.UTC)
+	require.NoError(t, err)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("weekly by day with count and exdate", func(t *testing.T) {
+		var days []int
+		for _, occ := range events[0].Occurrences(from, to) {
+			days = append(days, occ.Start.Day())
+		}
+		// Five occurrences Mo/We/Fr from the 4th; the 6th is excluded
+		assert.Equal(t, []int{4, 8, 11, 13}, days)
+	})
+
+	start := func(rule string) []time.Time {
+		t.Helper()
+		ics := "BEGIN:VEVENT\nDTSTART:20240131T120000\nRRULE:" + rule + "\nEND:VEVENT\n"
+		events, _, err := ParseICS(strings.NewReader(ics), time.UTC)
+		require.NoError(t, err)
+		var starts []time.Time
+		for _, occ := range events[0].Occurrences(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			starts = append(starts, occ.Start)
+		}
+		return starts
+	}
+
+	t.Run("monthly skips short months", func(t *testing.T) {
+		starts := start("FREQ=MONTHLY;COUNT=3")
+		require.Len(t, starts, 3)
+		assert.Equal(t, time.March, starts[1].Month())
+		assert.Equal(t, time.May, starts[2].Month())
+	})
+
+	t.Run("daily interval until", func(t *testing.T) {
+		starts := start("FREQ=DAILY;INTERVAL=2;UNTIL=20240206")
+		assert.Len(t, starts, 4, "31st, 2nd, 4th and 6th")
+	})
+
+	t.Run("yearly is bounded by the window", func(t *testing.T) {
+		assert.Len(t, start("FREQ=YEARLY"), 1)
+	})
+
+	t.Run("long running series reach the window", func(t *testing.T) {
+		// More than maxOccurrences days before the window
+		ics := "BEGIN:VEVENT\nDTSTART:19700101T090000\nRRULE:FREQ=DAILY\nEND:VEVENT\n" +
+			"BEGIN:VEVENT\nDTSTART:19900101T090000\nRRULE:FREQ=DAILY;INTERVAL=3\nEND:VEVENT\n"
+		events, _, err := ParseICS(strings.NewReader(ics), time.UTC)
+		require.NoError(t, err)
+		assert.Len(t, events[0].Occurrences(from, to), 31)
+		occs := events[1].Occurrences(from, to)
+		require.Len(t, occs, 10)
+		assert.Equal(t, 3, occs[0].Start.Day(), "fast-forwarding keeps the interval")
+	})
+}
+
+func TestBusyDays(t *testing.T) {
+	month := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+	busy := busyDays([]Occurrence{
+		{Start: at(4, 22), End: at(5, 1)},
+		{Start: at(10, 9), End: at(11, 0)},
+		{Start: at(20, 0), End: at(21, 0)},
+		{Start: at(25, 12), End: at(25, 12)},
+		{Start: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), End: at(2, 0)},
+	}, month)
+	assert.Equal(t, map[int]bool{1: true, 4: true, 5: true, 10: true, 20: true, 25: true}, busy)
+}
+
+func TestCalendarWidget(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "work.ics")
+	require.NoError(t, os.WriteFile(path, []byte(sampleICS), 0o644))
+
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.Local)
+	w := New(WithFiles(dir), WithNow(func() time.Time { return now }))
+	w.SetSize(70, 40)
+	w.Focus()
+
+	t.Run("env files", func(t *testing.T) {
+		t.Setenv(envFiles, "a.ics, cal/")
+		assert.Equal(t, []string{"a.ics", "cal/"}, New().paths)
+	})
+
+	view := w.View()
+	assert.Contains(t, view, "March 2024")
+	assert.Contains(t, view, "Mo Tu We Th Fr Sa Su")
+
+	w.Update(w.loadEvents())
+	view = w.View()
+	assert.Contains(t, view, "Today  all day Public holiday")
+	assert.Contains(t, view, "Today  09:30   Team standup")
+	assert.Contains(t, view, "@ Room 1, 2nd floor")
+	assert.Contains(t, view, "Mon 11 09:30   Team standup")
+	assert.NotContains(t, view, "Tokyo sync", "past events are not listed")
+
+	t.Run("reloads on file change", func(t *testing.T) {
+		_, cmd := w.Update(watchMsg{now: now, fingerprint: fingerprint(w.paths)})
+		require.NotNil(t, cmd)
+
+		extra := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Dentist\nDTSTART:20240309T140000\nEND:VEVENT\nEND:VCALENDAR\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "personal.ics"), []byte(extra), 0o644))
+
+		msg := watchMsg{now: now, fingerprint: fingerprint(w.paths)}
+		assert.NotEqual(t, w.fingerprint, msg.fingerprint)
+
+		w.Update(w.loadEvents())
+		assert.Contains(t, w.View(), "Tmrw   14:00   Dentist")
+	})
+
+	t.Run("month navigation", func(t *testing.T) {
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+		assert.Contains(t, w.View(), "April 2024")
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".")})
+		assert.Contains(t, w.View(), "March 2024")
+	})
+
+	t.Run("skipped events are shown", func(t *testing.T) {
+		broken := "BEGIN:VEVENT\nEND:VEVENT\n" +
+			"BEGIN:VEVENT\nSUMMARY:Hourly\nDTSTART:20240308T100000\nRRULE:FREQ=HOURLY\nEND:VEVENT\n" +
+			"BEGIN:VEVENT\nSUMMARY:Lunch\nDTSTART:20240308T120000\nEND:VEVENT\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.ics"), []byte(broken), 0o644))
+		w.Update(w.loadEvents())
+		view := w.View()
+		assert.Contains(t, view, "broken.ics: skipped event")
+		assert.Contains(t, view, "(+1 more)")
+		assert.Contains(t, view, "Lunch", "valid events of the file still load")
+		assert.Contains(t, view, "Dentist", "other calendars still load")
+		assert.Nil(t, w.lastError)
+	})
+}
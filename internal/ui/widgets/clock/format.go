@@ -0,0 +1,118 @@
+package clock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// strftime formats t using a subset of C strftime directives:
+// %Y %y %m %d %e %H %I %M %S %p %A %a %B %b %j %Z %z %n %%.
+// Unknown directives are written unchanged.
+func strftime(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'Y':
+			fmt.Fprintf(&b, "%d", t.Year())
+		case 'y':
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(&b, "%2d", t.Day())
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'I':
+			fmt.Fprintf(&b, "%02d", hour12(t))
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'S':
+			fmt.Fprintf(&b, "%02d", t.Second())
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'A':
+			b.WriteString(t.Weekday().String())
+		case 'a':
+			b.WriteString(t.Weekday().String()[:3])
+		case 'B':
+			b.WriteString(t.Month().String())
+		case 'b':
+			b.WriteString(t.Month().String()[:3])
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case 'Z':
+			b.WriteString(t.Format("MST"))
+		case 'z':
+			b.WriteString(t.Format("-0700"))
+		case 'n':
+			b.WriteByte('\n')
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+// hour12 returns the hour on a 12-hour clock
+func hour12(t time.Time) int {
+	h := t.Hour() % 12
+	if h == 0 {
+		return 12
+	}
+	return h
+}
+
+// blockHeight is the number of lines in a block glyph
+const blockHeight = 5
+
+// blockGlyphs is a 3x5 font for the characters used by the clock
+var blockGlyphs = map[rune][blockHeight]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {"██ ", " █ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	':': {" ", "█", " ", "█", " "},
+}
+
+// renderBlock renders s in block glyphs, one space between characters.
+// Characters without a glyph are skipped.
+func renderBlock(s string) string {
+	var lines [blockHeight]strings.Builder
+	first := true
+	for _, r := range s {
+		glyph, ok := blockGlyphs[r]
+		if !ok {
+			continue
+		}
+		for row := range lines {
+			if !first {
+				lines[row].WriteByte(' ')
+			}
+			lines[row].WriteString(glyph[row])
+		}
+		first = false
+	}
+
+	rows := make([]string, blockHeight)
+	for i := range lines {
+		rows[i] = lines[i].String()
+	}
+	return strings.Join(rows, "\n")
+}
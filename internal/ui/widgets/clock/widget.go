@@ -0,0 +1,305 @@
+// Package clock provides a widget for local time and world clocks
+package clock
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	defaultDateFormat = "%A %d %B %Y"
+	format24h         = "%H:%M:%S"
+	format12h         = "%I:%M:%S %p"
+	zoneFormat24h     = "%H:%M"
+	zoneFormat12h     = "%I:%M %p"
+
+	envZones      = "DASHBOARD_CLOCK_ZONES"
+	env24Hour     = "DASHBOARD_CLOCK_24H"
+	envTimeFormat = "DASHBOARD_CLOCK_TIME_FORMAT"
+	envDateFormat = "DASHBOARD_CLOCK_DATE_FORMAT"
+
+	// chromeWidth is the border and padding around the widget content
+	chromeWidth = 4
+	// dayStart and dayEnd bound the hours shown as daytime
+	dayStart = 6
+	dayEnd   = 18
+)
+
+// Zone is a labelled time zone
+type Zone struct {
+	Label    string
+	Location *time.Location
+}
+
+// Widget represents the clock widget
+type Widget struct {
+	components.BaseWidget
+	zones      []Zone
+	use24h     bool
+	timeFormat string
+	dateFormat string
+	now        func() time.Time
+
+	current   time.Time
+	zoneError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithZones sets the world clock zones
+func WithZones(zones ...Zone) Option {
+	return func(w *Widget) {
+		w.zones = zones
+		w.zoneError = nil
+	}
+}
+
+// With24Hour selects a 24-hour (true) or 12-hour (false) clock
+func With24Hour(use24h bool) Option {
+	return func(w *Widget) {
+		w.use24h = use24h
+	}
+}
+
+// WithTimeFormat sets a strftime-like format for the local time
+func WithTimeFormat(format string) Option {
+	return func(w *Widget) {
+		w.timeFormat = format
+	}
+}
+
+// WithDateFormat sets a strftime-like format for the date line
+func WithDateFormat(format string) Option {
+	return func(w *Widget) {
+		w.dateFormat = format
+	}
+}
+
+// WithNow sets the time source, mainly for tests
+func WithNow(now func() time.Time) Option {
+	return func(w *Widget) {
+		w.now = now
+	}
+}
+
+// New creates a new clock widget. Zones default to DASHBOARD_CLOCK_ZONES, a
+// comma-separated list of IANA names optionally labelled as Label=Area/City.
+func New(opts ...Option) *Widget {
+	zones, err := ParseZones(os.Getenv(envZones))
+
+	use24h := true
+	if v, parseErr := strconv.ParseBool(os.Getenv(env24Hour)); parseErr == nil {
+		use24h = v
+	}
+
+	dateFormat := os.Getenv(envDateFormat)
+	if dateFormat == "" {
+		dateFormat = defaultDateFormat
+	}
+
+	w := &Widget{
+		zones:      zones,
+		zoneError:  err,
+		use24h:     use24h,
+		timeFormat: os.Getenv(envTimeFormat),
+		dateFormat: dateFormat,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.current = w.now()
+	return w
+}
+
+// ParseZones parses a comma-separated zone list such as
+// "Europe/London,Tokyo=Asia/Tokyo". Invalid zones are reported in the error
+// and skipped.
+func ParseZones(s string) ([]Zone, error) {
+	var zones []Zone
+	var invalid []string
+	for _, item := range format.SplitList(s) {
+		label, name, ok := strings.Cut(item, "=")
+		if !ok {
+			name = item
+			label = strings.ReplaceAll(name[strings.LastIndex(name, "/")+1:], "_", " ")
+		}
+		loc, err := time.LoadLocation(strings.TrimSpace(name))
+		if err != nil {
+			invalid = append(invalid, name)
+			continue
+		}
+		zones = append(zones, Zone{Label: strings.TrimSpace(label), Location: loc})
+	}
+	if len(invalid) > 0 {
+		return zones, fmt.Errorf("unknown time zone: %s", strings.Join(invalid, ", "))
+	}
+	return zones, nil
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.tick()
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if w.IsFocused() && msg.String() == "t" {
+			w.use24h = !w.use24h
+		}
+	case tickMsg:
+		w.current = time.Time(msg)
+		return w, w.tick()
+	}
+	return w, nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	now := w.current
+
+	b.WriteString(styles.Title.Render("Clock"))
+	b.WriteString("\n\n")
+
+	timeText := strftime(now, w.localFormat())
+	digits, suffix := splitBlockText(timeText)
+	block := renderBlock(digits)
+	// Block digits need room for the title, date and zones below them, and
+	// only a non-numeric suffix such as AM/PM may remain as text
+	if digits != "" && !strings.ContainsAny(suffix, "0123456789") &&
+		lipgloss.Width(block)+chromeWidth <= width &&
+		blockHeight+len(w.zones)+8 <= height {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Render(block))
+		if suffix != "" {
+			b.WriteString("\n" + suffix)
+		}
+	} else {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(timeText))
+	}
+	b.WriteString("\n")
+	b.WriteString(subtle.Render(strftime(now, w.dateFormat)))
+	b.WriteString("\n")
+
+	if len(w.zones) > 0 {
+		b.WriteString("\n")
+	}
+	for _, z := range w.zones {
+		b.WriteString(w.renderZone(now, z))
+		b.WriteString("\n")
+	}
+	if w.zoneError != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.zoneError.Error()))
+		b.WriteString("\n")
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("t: 12/24h"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderZone formats a world clock line with a day/night marker, the weekday
+// when it differs from local time, and the offset from local time
+func (w *Widget) renderZone(now time.Time, z Zone) string {
+	t := now.In(z.Location)
+
+	marker := lipgloss.NewStyle().Foreground(styles.Secondary).Render("☾")
+	if t.Hour() >= dayStart && t.Hour() < dayEnd {
+		marker = lipgloss.NewStyle().Foreground(styles.Warning).Render("☀")
+	}
+
+	format := zoneFormat24h
+	if !w.use24h {
+		format = zoneFormat12h
+	}
+
+	line := fmt.Sprintf("%s %-14s %s", marker, z.Label, strftime(t, format))
+	if t.Weekday() != now.Weekday() {
+		line += " " + t.Weekday().String()[:3]
+	}
+	return line + " " + lipgloss.NewStyle().Foreground(styles.Subtle).Render(formatOffset(now, t))
+}
+
+// localFormat returns the format for the main clock
+func (w *Widget) localFormat() string {
+	switch {
+	case w.timeFormat != "":
+		return w.timeFormat
+	case w.use24h:
+		return format24h
+	}
+	return format12h
+}
+
+// splitBlockText splits text into the leading part that has block glyphs and
+// the remainder, e.g. "03:04:05 PM" into "03:04:05" and "PM"
+func splitBlockText(text string) (digits, rest string) {
+	end := 0
+	for i, r := range text {
+		if _, ok := blockGlyphs[r]; !ok {
+			break
+		}
+		end = i + len(string(r))
+	}
+	return text[:end], strings.TrimSpace(text[end:])
+}
+
+// formatOffset formats the difference between the UTC offsets of t and local
+func formatOffset(local, t time.Time) string {
+	_, localOffset := local.Zone()
+	_, offset := t.Zone()
+	diff := time.Duration(offset-localOffset) * time.Second
+
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	hours := int(diff.Hours())
+	minutes := int(diff.Minutes()) % 60
+	switch {
+	case diff == 0:
+		return "±0h"
+	case minutes != 0:
+		return fmt.Sprintf("%s%dh%02d", sign, hours, minutes)
+	}
+	return fmt.Sprintf("%s%dh", sign, hours)
+}
+
+// Message types for the clock widget
+type tickMsg time.Time
+
+// Commands
+
+// tick fires on the next whole second of the system clock, so the display
+// changes at the same moment as the wall clock
+func (w *Widget) tick() tea.Cmd {
+	now := w.now
+	return tea.Every(time.Second, func(time.Time) tea.Msg {
+		return tickMsg(now())
+	})
+}
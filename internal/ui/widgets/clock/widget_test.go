@@ -0,0 +1,126 @@
+package clock
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrftime(t *testing.T) {
+	ts := time.Date(2024, time.March, 5, 15, 4, 9, 0, time.UTC)
+
+	assert.Equal(t, "2024-03-05 15:04:09", strftime(ts, "%Y-%m-%d %H:%M:%S"))
+	assert.Equal(t, "03:04 PM", strftime(ts, "%I:%M %p"))
+	assert.Equal(t, "Tue Mar  5 '24", strftime(ts, "%a %b %e '%y"))
+	assert.Equal(t, "Tuesday, March 065 UTC +0000", strftime(ts, "%A, %B %j %Z %z"))
+	assert.Equal(t, "100% %q", strftime(ts, "100%% %q"))
+	assert.Equal(t, "12", strftime(ts.Add(-3*time.Hour), "%I"), "noon is 12")
+}
+
+func TestParseZones(t *testing.T) {
+	zones, err := ParseZones("Europe/London, Tokyo=Asia/Tokyo,America/New_York")
+	require.NoError(t, err)
+	require.Len(t, zones, 3)
+	assert.Equal(t, "London", zones[0].Label)
+	assert.Equal(t, "Tokyo", zones[1].Label)
+	assert.Equal(t, "Asia/Tokyo", zones[1].Location.String())
+	assert.Equal(t, "New York", zones[2].Label)
+
+	zones, err = ParseZones("Mars/Olympus,UTC")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Mars/Olympus")
+	assert.Len(t, zones, 1, "valid zones are kept")
+}
+
+func TestRenderBlock(t *testing.T) {
+	block := renderBlock("1:0")
+	lines := strings.Split(block, "\n")
+	require.Len(t, lines, blockHeight)
+	assert.Equal(t, "██    ███", lines[0])
+	assert.Equal(t, " █  █ █ █", lines[1])
+
+	digits, rest := splitBlockText("03:04:05 PM")
+	assert.Equal(t, "03:04:05", digits)
+	assert.Equal(t, "PM", rest)
+}
+
+func TestClockWidget(t *testing.T) {
+	// 22:30 UTC is 07:30 the next day in Tokyo
+	now := time.Date(2024, time.March, 5, 22, 30, 15, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	india, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	newWidget := func(opts ...Option) *Widget {
+		opts = append([]Option{
+			WithNow(func() time.Time { return now }),
+			WithZones(Zone{Label: "Tokyo", Location: tokyo}, Zone{Label: "Mumbai", Location: india}),
+		}, opts...)
+		return New(opts...)
+	}
+
+	t.Run("env configuration", func(t *testing.T) {
+		t.Setenv(envZones, "Asia/Tokyo,Nowhere/City")
+		t.Setenv(env24Hour, "false")
+		t.Setenv(envDateFormat, "%d/%m")
+		w := New()
+		require.Len(t, w.zones, 1)
+		assert.Error(t, w.zoneError)
+		assert.False(t, w.use24h)
+		assert.Equal(t, "%d/%m", w.dateFormat)
+	})
+
+	t.Run("block digits when space allows", func(t *testing.T) {
+		w := newWidget()
+		w.SetSize(60, 30)
+		view := w.View()
+		assert.Contains(t, view, renderBlock("22:30:15")[:len("███")])
+		assert.NotContains(t, view, "22:30:15")
+		assert.Contains(t, view, "Tuesday 05 March 2024")
+		assert.Contains(t, view, "☀ Tokyo")
+		assert.Contains(t, view, "07:30 Wed +9h")
+		assert.Contains(t, view, "☾ Mumbai")
+		assert.Contains(t, view, "04:00 Wed +5h30")
+	})
+
+	t.Run("plain text when narrow", func(t *testing.T) {
+		w := newWidget()
+		w.SetSize(20, 30)
+		assert.Contains(t, w.View(), "22:30:15")
+	})
+
+	t.Run("12 hour toggle and custom format", func(t *testing.T) {
+		w := newWidget(WithTimeFormat("%H.%M"))
+		w.SetSize(20, 30)
+		assert.Contains(t, w.View(), "22.30")
+
+		w = newWidget()
+		w.SetSize(60, 30)
+		w.Focus()
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+		view := w.View()
+		assert.Contains(t, view, "PM")
+		assert.Contains(t, view, "07:30 AM Wed")
+	})
+
+	t.Run("tick updates time", func(t *testing.T) {
+		w := newWidget()
+		w.SetSize(20, 30)
+		_, cmd := w.Update(tickMsg(now.Add(time.Minute)))
+		assert.NotNil(t, cmd, "expected next tick")
+		assert.Contains(t, w.View(), "22:31:15")
+	})
+}
+
+func TestFormatOffset(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	assert.Equal(t, "-5h", formatOffset(now, now.In(ny)))
+	assert.Equal(t, "±0h", formatOffset(now, now))
+}
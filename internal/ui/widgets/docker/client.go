@@ -0,0 +1,305 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/dashboard/internal/logger"
+)
+
+// Default configuration
+const (
+	defaultSocketPath = "/var/run/docker.sock"
+	defaultAPITimeout = 10 * time.Second
+	envDockerSocket   = "DASHBOARD_DOCKER_SOCKET"
+
+	// stopGracePeriod is how long the engine waits after SIGTERM before
+	// killing a container that is stopped or restarted; those requests get
+	// this on top of the API timeout
+	stopGracePeriod = 10 * time.Second
+
+	// baseURL is a placeholder host; requests are always dialed to the socket
+	baseURL = "http://docker"
+)
+
+// Client talks to the Docker Engine API (or Podman's compatible API) over a Unix socket
+type Client struct {
+	socketPath string
+	timeout    time.Duration
+	httpClient *http.Client
+	logger     logger.Logger
+}
+
+// ClientOption allows configuring the client
+type ClientOption func(*Client)
+
+// WithSocketPath sets the engine socket path
+func WithSocketPath(path string) ClientOption {
+	return func(c *Client) {
+		c.socketPath = path
+	}
+}
+
+// WithTimeout sets a custom timeout for API requests
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithLogger sets the logger for the client
+func WithLogger(l logger.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// NewClient creates a new engine API client. The socket path defaults to
+// DASHBOARD_DOCKER_SOCKET, then /var/run/docker.sock.
+func NewClient(log logger.Logger, opts ...ClientOption) *Client {
+	socketPath := os.Getenv(envDockerSocket)
+	if socketPath == "" {
+		socketPath = defaultSocketPath
+	}
+
+	c := &Client{
+		socketPath: socketPath,
+		timeout:    defaultAPITimeout,
+		logger:     log,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Timeouts are set per request, since stopping a container can take
+	// longer than other calls
+	dialer := &net.Dialer{}
+	c.httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", c.socketPath)
+			},
+		},
+	}
+
+	c.logger.Debug("Docker client initialized",
+		logger.NewField("socket", c.socketPath),
+		logger.NewField("timeout", c.timeout),
+	)
+
+	return c
+}
+
+// Container is an entry of the container list
+type Container struct {
+	ID     string   `json:"Id"`
+	Names  []string `json:"Names"`
+	Image  string   `json:"Image"`
+	State  string   `json:"State"`
+	Status string   `json:"Status"`
+}
+
+// Name returns the primary container name without the leading slash
+func (c Container) Name() string {
+	if len(c.Names) == 0 {
+		return shortID(c.ID)
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+// Stats is the resource usage of a running container
+type Stats struct {
+	CPUPercent  float64
+	MemoryUsage uint64
+	MemoryLimit uint64
+}
+
+// statsResponse is the subset of the stats endpoint used by the widget
+type statsResponse struct {
+	CPUStats    cpuStats `json:"cpu_stats"`
+	PreCPUStats cpuStats `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+}
+
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// ListContainers retrieves all containers, including stopped ones
+func (c *Client) ListContainers() ([]Container, error) {
+	var containers []Container
+	if err := c.get("/containers/json?all=1", &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// Stats retrieves a single stats sample for a running container
+func (c *Client) Stats(id string) (Stats, error) {
+	var resp statsResponse
+	if err := c.get("/containers/"+url.PathEscape(id)+"/stats?stream=false", &resp); err != nil {
+		return Stats{}, err
+	}
+	return resp.toStats(), nil
+}
+
+// Start starts a container
+func (c *Client) Start(id string) error {
+	return c.post("/containers/"+url.PathEscape(id)+"/start", c.timeout)
+}
+
+// Stop stops a container, killing it if it is still running after the
+// grace period
+func (c *Client) Stop(id string) error {
+	return c.post(c.gracefulPath(id, "stop"), c.timeout+stopGracePeriod)
+}
+
+// Restart restarts a container, killing it if it is still running after
+// the grace period
+func (c *Client) Restart(id string) error {
+	return c.post(c.gracefulPath(id, "restart"), c.timeout+stopGracePeriod)
+}
+
+// gracefulPath returns the path of a stop or restart request with an
+// explicit grace period, so the engine's default can't outlast the timeout
+func (c *Client) gracefulPath(id, action string) string {
+	return fmt.Sprintf("/containers/%s/%s?t=%d", url.PathEscape(id), action, int(stopGracePeriod.Seconds()))
+}
+
+// toStats computes CPU percentage and memory usage the same way as `docker stats`
+func (r statsResponse) toStats() Stats {
+	stats := Stats{
+		MemoryUsage: r.MemoryStats.Usage,
+		MemoryLimit: r.MemoryStats.Limit,
+	}
+
+	// Page cache is not counted as used; cgroup v2 reports inactive_file, v1 cache
+	if inactive, ok := r.MemoryStats.Stats["inactive_file"]; ok && inactive < stats.MemoryUsage {
+		stats.MemoryUsage -= inactive
+	} else if cache, ok := r.MemoryStats.Stats["cache"]; ok && cache < stats.MemoryUsage {
+		stats.MemoryUsage -= cache
+	}
+
+	cpus := float64(r.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(r.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if r.CPUStats.CPUUsage.TotalUsage > r.PreCPUStats.CPUUsage.TotalUsage &&
+		r.CPUStats.SystemUsage > r.PreCPUStats.SystemUsage {
+		cpuDelta := float64(r.CPUStats.CPUUsage.TotalUsage - r.PreCPUStats.CPUUsage.TotalUsage)
+		systemDelta := float64(r.CPUStats.SystemUsage - r.PreCPUStats.SystemUsage)
+		stats.CPUPercent = cpuDelta / systemDelta * cpus * 100
+	}
+
+	return stats
+}
+
+// get performs a GET request and decodes the JSON response into v
+func (c *Client) get(path string, v interface{}) error {
+	c.logger.Debug("Making docker request",
+		logger.NewField("method", http.MethodGet),
+		logger.NewField("path", path),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Error("Failed to make request",
+			logger.NewField("error", err),
+		)
+		return fmt.Errorf("failed to reach docker at %s: %w", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.statusError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		c.logger.Error("Failed to decode response",
+			logger.NewField("error", err),
+		)
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// post performs a POST request without a body, waiting up to timeout; 204
+// and 304 (already in the requested state) are treated as success
+func (c *Client) post(path string, timeout time.Duration) error {
+	c.logger.Debug("Making docker request",
+		logger.NewField("method", http.MethodPost),
+		logger.NewField("path", path),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Error("Failed to make request",
+			logger.NewField("error", err),
+		)
+		return fmt.Errorf("failed to reach docker at %s: %w", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotModified {
+		return c.statusError(resp)
+	}
+	return nil
+}
+
+// statusError builds an error from the engine's {"message": "..."} body
+func (c *Client) statusError(resp *http.Response) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(data, &body); err != nil || body.Message == "" {
+		body.Message = resp.Status
+	}
+
+	c.logger.Error("Unexpected status code",
+		logger.NewField("status_code", resp.StatusCode),
+		logger.NewField("message", body.Message),
+	)
+	return fmt.Errorf("docker: %s", body.Message)
+}
+
+// shortID returns the 12 character short form of a container ID
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,397 @@
+// Package docker provides a widget for listing and managing Docker or Podman containers
+package docker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/logger"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+const (
+	defaultInterval = 5 * time.Second
+	// chromeHeight is the number of lines used by borders, padding, title,
+	// table header, status and help text
+	chromeHeight = 9
+	// maxStatsRequests limits concurrent stats requests to the engine
+	maxStatsRequests = 4
+)
+
+// action is a container lifecycle operation
+type action string
+
+const (
+	actionStart   action = "start"
+	actionStop    action = "stop"
+	actionRestart action = "restart"
+)
+
+// row is a container with its latest resource usage
+type row struct {
+	Container
+	stats *Stats
+}
+
+// pendingAction is an action awaiting confirmation
+type pendingAction struct {
+	id     string
+	name   string
+	action action
+}
+
+// Widget represents the container list widget
+type Widget struct {
+	components.BaseWidget
+	client   *Client
+	logger   logger.Logger
+	interval time.Duration
+
+	rows       []row
+	selected   int
+	selectedID string
+	offset     int
+
+	confirm   *pendingAction
+	status    string
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithClient sets the engine API client, mainly for tests
+func WithClient(client *Client) Option {
+	return func(w *Widget) {
+		w.client = client
+	}
+}
+
+// WithInterval sets the refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new container widget
+func New(log logger.Logger, opts ...Option) *Widget {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	w := &Widget{
+		logger:   log,
+		interval: defaultInterval,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if w.client == nil {
+		w.client = NewClient(log)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.fetchContainers
+}
+
+// CapturingInput implements components.InputCapturer
+func (w *Widget) CapturingInput() bool {
+	return w.confirm != nil
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		return w, w.handleKey(msg)
+	case containersMsg:
+		if msg.err != nil {
+			w.lastError = msg.err
+		} else {
+			w.lastError = nil
+			w.updateRows(msg.rows)
+		}
+		if msg.manual {
+			// The interval refresh is already scheduled
+			return w, nil
+		}
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.fetchContainers
+	case actionResultMsg:
+		if msg.err != nil {
+			w.logger.Error("Container action failed",
+				logger.NewField("container", msg.name),
+				logger.NewField("action", string(msg.action)),
+				logger.NewField("error", msg.err),
+			)
+			w.lastError = msg.err
+			return w, nil
+		}
+		w.logger.Info("Container action succeeded",
+			logger.NewField("container", msg.name),
+			logger.NewField("action", string(msg.action)),
+		)
+		w.status = fmt.Sprintf("%s: %s done", msg.name, msg.action)
+		return w, w.fetchManual
+	}
+	return w, nil
+}
+
+// handleKey processes a key press while focused
+func (w *Widget) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if w.confirm != nil {
+		pending := *w.confirm
+		w.confirm = nil
+		if msg.String() == "y" {
+			return w.runAction(pending)
+		}
+		w.status = "Cancelled"
+		return nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		w.moveSelection(-1)
+	case "down", "j":
+		w.moveSelection(1)
+	case "s":
+		// Starting is harmless, so it runs without confirmation
+		if c, ok := w.current(); ok {
+			w.status = ""
+			w.lastError = nil
+			return w.runAction(pendingAction{id: c.ID, name: c.Name(), action: actionStart})
+		}
+	case "x":
+		w.requestAction(actionStop)
+	case "r":
+		w.requestAction(actionRestart)
+	}
+	return nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	running := 0
+	for _, r := range w.rows {
+		if r.State == "running" {
+			running++
+		}
+	}
+	b.WriteString(styles.Title.Render(fmt.Sprintf("Containers (%d/%d running)", running, len(w.rows))))
+	b.WriteString("\n\n")
+
+	w.renderTable(&b, max(height-chromeHeight, 1))
+
+	b.WriteString("\n")
+	switch {
+	case w.confirm != nil:
+		warn := lipgloss.NewStyle().Foreground(styles.Secondary).Bold(true)
+		b.WriteString(warn.Render(fmt.Sprintf("%s container %s? y/n", w.confirm.action, w.confirm.name)))
+	case w.lastError != nil:
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+	case w.status != "":
+		b.WriteString(subtle.Render(w.status))
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("↑/↓: select • s: start • x: stop • r: restart"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderTable writes at most maxRows containers, scrolled to keep the selection visible
+func (w *Widget) renderTable(b *strings.Builder, maxRows int) {
+	b.WriteString(styles.Title.Render(fmt.Sprintf("%-20s %-9s %6s %10s %s", "NAME", "STATE", "CPU%", "MEM", "IMAGE")))
+	b.WriteString("\n")
+
+	if len(w.rows) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render("No containers"))
+		return
+	}
+
+	if w.selected < w.offset {
+		w.offset = w.selected
+	} else if w.selected >= w.offset+maxRows {
+		w.offset = w.selected - maxRows + 1
+	}
+
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true)
+	end := min(w.offset+maxRows, len(w.rows))
+	for i := w.offset; i < end; i++ {
+		r := w.rows[i]
+		cpu, mem := "-", "-"
+		if r.stats != nil {
+			cpu = fmt.Sprintf("%.1f", r.stats.CPUPercent)
+			mem = format.Bytes(r.stats.MemoryUsage)
+		}
+		state := lipgloss.NewStyle().Foreground(stateColor(r.State)).Render(fmt.Sprintf("%-9s", r.State))
+		line := fmt.Sprintf("%-20s %s %6s %10s %s", format.Truncate(r.Name(), 20), state, cpu, mem, r.Image)
+		if i == w.selected && w.IsFocused() {
+			line = selectedStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// updateRows replaces the container list, keeping the selection on the same container
+func (w *Widget) updateRows(rows []row) {
+	w.rows = rows
+	w.selected = 0
+	for i, r := range rows {
+		if r.ID == w.selectedID {
+			w.selected = i
+			break
+		}
+	}
+	if len(rows) > 0 {
+		w.selectedID = rows[w.selected].ID
+	}
+}
+
+// moveSelection moves the cursor by delta rows within bounds
+func (w *Widget) moveSelection(delta int) {
+	next := w.selected + delta
+	if next < 0 || next >= len(w.rows) {
+		return
+	}
+	w.selected = next
+	w.selectedID = w.rows[next].ID
+}
+
+// current returns the selected container
+func (w *Widget) current() (row, bool) {
+	if w.selected < 0 || w.selected >= len(w.rows) {
+		return row{}, false
+	}
+	return w.rows[w.selected], true
+}
+
+// requestAction asks for confirmation before acting on the selected container
+func (w *Widget) requestAction(a action) {
+	c, ok := w.current()
+	if !ok {
+		return
+	}
+	w.status = ""
+	w.lastError = nil
+	w.confirm = &pendingAction{id: c.ID, name: c.Name(), action: a}
+}
+
+// stateColor returns the display color of a container state
+func stateColor(state string) lipgloss.Color {
+	switch state {
+	case "running":
+		return styles.Primary
+	case "restarting", "paused":
+		return styles.Warning
+	case "dead":
+		return styles.Critical
+	}
+	return styles.Subtle
+}
+
+// Message types for the container widget
+type containersMsg struct {
+	rows []row
+	err  error
+	// manual is set for refreshes outside the interval, such as after an action
+	manual bool
+}
+
+type refreshMsg struct{}
+
+type actionResultMsg struct {
+	name   string
+	action action
+	err    error
+}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) fetchContainers() tea.Msg {
+	containers, err := w.client.ListContainers()
+	if err != nil {
+		return containersMsg{err: err}
+	}
+
+	// The engine takes a second or two per stats sample, so they are
+	// fetched in parallel, a few at a time
+	rows := make([]row, len(containers))
+	sem := make(chan struct{}, maxStatsRequests)
+	var wg sync.WaitGroup
+	for i, c := range containers {
+		rows[i] = row{Container: c}
+		if c.State != "running" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			// Stats are best effort; a container may stop between list and stats
+			if stats, err := w.client.Stats(c.ID); err == nil {
+				rows[i].stats = &stats
+			}
+		}()
+	}
+	wg.Wait()
+	return containersMsg{rows: rows}
+}
+
+func (w *Widget) fetchManual() tea.Msg {
+	msg := w.fetchContainers().(containersMsg)
+	msg.manual = true
+	return msg
+}
+
+func (w *Widget) runAction(p pendingAction) tea.Cmd {
+	client := w.client
+	return func() tea.Msg {
+		var err error
+		switch p.action {
+		case actionStart:
+			err = client.Start(p.id)
+		case actionStop:
+			err = client.Stop(p.id)
+		case actionRestart:
+			err = client.Restart(p.id)
+		}
+		return actionResultMsg{name: p.name, action: p.action, err: err}
+	}
+}
@@ -0,0 +1,201 @@
+package docker
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jonesrussell/dashboard/internal/testutil/testlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEngine is a minimal engine API recording lifecycle requests
+type fakeEngine struct {
+	mu      sync.Mutex
+	actions []string
+}
+
+func (e *fakeEngine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/containers/json":
+		json.NewEncoder(w).Encode([]Container{
+			{ID: "aaaaaaaaaaaaaaaa", Names: []string{"/web"}, Image: "nginx:1.27", State: "running", Status: "Up 2 hours"},
+			{ID: "bbbbbbbbbbbbbbbb", Names: []string{"/db"}, Image: "postgres:16", State: "exited", Status: "Exited (0)"},
+		})
+	case r.Method == http.MethodGet && r.URL.Path == "/containers/aaaaaaaaaaaaaaaa/stats":
+		w.Write([]byte(`{
+			"cpu_stats": {"cpu_usage": {"total_usage": 300000000}, "system_cpu_usage": 2000000000, "online_cpus": 2},
+			"precpu_stats": {"cpu_usage": {"total_usage": 200000000}, "system_cpu_usage": 1000000000},
+			"memory_stats": {"usage": 10485760, "limit": 104857600, "stats": {"inactive_file": 2097152}}
+		}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/containers/missing/start":
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "No such container: missing"}`))
+	case r.Method == http.MethodPost:
+		e.mu.Lock()
+		e.actions = append(e.actions, r.URL.RequestURI())
+		e.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// startEngine serves engine on a Unix socket and returns its path
+func startEngine(t *testing.T, engine http.Handler) string {
+	t.Helper()
+
+	// Socket paths are limited to ~100 bytes, so avoid the long t.TempDir()
+	dir, err := os.MkdirTemp("", "docker")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "docker.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(engine)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return socket
+}
+
+func TestClient(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "docker-client")
+	engine := &fakeEngine{}
+	client := NewClient(log, WithSocketPath(startEngine(t, engine)))
+
+	t.Run("list containers", func(t *testing.T) {
+		containers, err := client.ListContainers()
+		require.NoError(t, err)
+		require.Len(t, containers, 2)
+		assert.Equal(t, "web", containers[0].Name())
+		assert.Equal(t, "exited", containers[1].State)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		stats, err := client.Stats("aaaaaaaaaaaaaaaa")
+		require.NoError(t, err)
+		assert.InDelta(t, 20, stats.CPUPercent, 0.001)
+		assert.Equal(t, uint64(8<<20), stats.MemoryUsage, "inactive file cache excluded")
+		assert.Equal(t, uint64(100<<20), stats.MemoryLimit)
+	})
+
+	t.Run("actions", func(t *testing.T) {
+		require.NoError(t, client.Restart("bbbbbbbbbbbbbbbb"))
+		assert.Equal(t, []string{"/containers/bbbbbbbbbbbbbbbb/restart?t=10"}, engine.actions)
+
+		err := client.Start("missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "No such container: missing")
+	})
+
+	t.Run("unreachable socket", func(t *testing.T) {
+		client := NewClient(log, WithSocketPath(filepath.Join(t.TempDir(), "none.sock")))
+		_, err := client.ListContainers()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to reach docker")
+	})
+
+	t.Run("env socket", func(t *testing.T) {
+		t.Setenv(envDockerSocket, "/run/podman/podman.sock")
+		assert.Equal(t, "/run/podman/podman.sock", NewClient(log).socketPath)
+	})
+}
+
+func TestFetchStatsInParallel(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "docker-stats")
+	var active, peak atomic.Int32
+	engine := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/containers/json" {
+			var containers []Container
+			for _, id := range []string{"a1", "a2", "a3", "a4", "a5", "a6"} {
+				containers = append(containers, Container{ID: id, State: "running"})
+			}
+			json.NewEncoder(w).Encode(containers)
+			return
+		}
+		// Stats take a while, as with a real engine
+		n := active.Add(1)
+		defer active.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"memory_stats": {"usage": 1048576}}`))
+	})
+
+	w := New(log, WithClient(NewClient(log, WithSocketPath(startEngine(t, engine)))))
+	msg, ok := w.fetchContainers().(containersMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	for _, r := range msg.rows {
+		assert.NotNil(t, r.stats, r.ID)
+	}
+	assert.Greater(t, peak.Load(), int32(1), "requests overlap")
+	assert.LessOrEqual(t, peak.Load(), int32(maxStatsRequests))
+}
+
+func TestDockerWidget(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "docker-widget")
+	engine := &fakeEngine{}
+	w := New(log, WithClient(NewClient(log, WithSocketPath(startEngine(t, engine)))))
+	w.SetSize(100, 30)
+	w.Focus()
+
+	_, cmd := w.Update(w.fetchContainers())
+	assert.NotNil(t, cmd, "expected next tick")
+
+	view := w.View()
+	assert.Contains(t, view, "Containers (1/2 running)")
+	assert.Contains(t, view, "web")
+	assert.Contains(t, view, "20.0")
+	assert.Contains(t, view, "8.0 MiB")
+	assert.Contains(t, view, "postgres:16")
+
+	t.Run("stop requires confirmation", func(t *testing.T) {
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		assert.True(t, w.CapturingInput())
+		assert.Contains(t, w.View(), "stop container web? y/n")
+
+		_, cmd := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+		assert.Nil(t, cmd)
+		assert.Contains(t, w.View(), "Cancelled")
+
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		_, cmd = w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		require.NotNil(t, cmd)
+		_, cmd = w.Update(cmd())
+		require.NotNil(t, cmd, "expected refresh")
+		assert.Contains(t, w.View(), "web: stop done")
+		assert.Contains(t, engine.actions, "/containers/aaaaaaaaaaaaaaaa/stop?t=10")
+		_, cmd = w.Update(cmd())
+		assert.Nil(t, cmd, "the interval refresh is already scheduled")
+	})
+
+	t.Run("start runs immediately and keeps selection", func(t *testing.T) {
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+		w.Update(w.fetchContainers())
+		assert.Equal(t, "bbbbbbbbbbbbbbbb", w.rows[w.selected].ID)
+
+		_, cmd := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		require.NotNil(t, cmd)
+		w.Update(cmd())
+		assert.Contains(t, engine.actions, "/containers/bbbbbbbbbbbbbbbb/start")
+	})
+
+	t.Run("action error", func(t *testing.T) {
+		w.Update(actionResultMsg{name: "db", action: actionStop, err: assert.AnError})
+		assert.Contains(t, w.View(), assert.AnError.Error())
+	})
+}
@@ -0,0 +1,175 @@
+package gitstatus
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status is the state of a single repository
+type Status struct {
+	Path   string
+	GitDir string
+
+	Branch   string
+	Detached bool
+	Upstream string
+	Ahead    int
+	Behind   int
+
+	Staged    int
+	Dirty     int
+	Untracked int
+	Conflicts int
+
+	// Subject and CommittedAt describe HEAD; both are empty in a new repository
+	Subject     string
+	CommittedAt time.Time
+}
+
+// Name returns the directory name of the repository
+func (s Status) Name() string {
+	return filepath.Base(s.Path)
+}
+
+// Clean reports whether the working tree has no changes
+func (s Status) Clean() bool {
+	return s.Staged == 0 && s.Dirty == 0 && s.Untracked == 0 && s.Conflicts == 0
+}
+
+// readStatus collects the status of the repository at path by running git
+func readStatus(path string) (Status, error) {
+	status := Status{Path: path}
+
+	gitDir, err := runGit(path, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return status, fmt.Errorf("%s: not a git repository: %w", path, err)
+	}
+	status.GitDir = strings.TrimSpace(gitDir)
+
+	out, err := runGit(path, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return status, fmt.Errorf("%s: git status failed: %w", path, err)
+	}
+	if err := parseStatus(strings.NewReader(out), &status); err != nil {
+		return status, err
+	}
+
+	// Fails in a repository without commits, which is not an error here
+	if out, err := runGit(path, "log", "-1", "--format=%ct%x00%s"); err == nil {
+		if ts, subject, ok := strings.Cut(strings.TrimSpace(out), "\x00"); ok {
+			if secs, err := strconv.ParseInt(ts, 10, 64); err == nil {
+				status.CommittedAt = time.Unix(secs, 0)
+			}
+			status.Subject = subject
+		}
+	}
+
+	return status, nil
+}
+
+// parseStatus fills s from `git status --porcelain=v2 --branch` output
+func parseStatus(r io.Reader, s *Status) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			s.Branch = strings.TrimPrefix(line, "# branch.head ")
+			s.Detached = s.Branch == "(detached)"
+		case strings.HasPrefix(line, "# branch.upstream "):
+			s.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &s.Ahead, &s.Behind)
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
+			// "1 XY ..." where X is the index and Y the working tree state
+			if len(line) < 4 {
+				continue
+			}
+			if line[2] != '.' {
+				s.Staged++
+			}
+			if line[3] != '.' {
+				s.Dirty++
+			}
+		case strings.HasPrefix(line, "u "):
+			s.Conflicts++
+		case strings.HasPrefix(line, "? "):
+			s.Untracked++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to parse git status: %w", err)
+	}
+	return nil
+}
+
+// fingerprint summarizes the files git updates on commit, checkout, staging
+// and fetch, plus the top-level entries of the working tree, so changes can be
+// detected without running git. Edits to files in subdirectories only change
+// that directory, so they show up on the next full refresh instead.
+func fingerprint(s Status) string {
+	if s.GitDir == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, p := range []string{
+		s.Path,
+		filepath.Join(s.GitDir, "HEAD"),
+		filepath.Join(s.GitDir, "index"),
+		filepath.Join(s.GitDir, "logs", "HEAD"),
+		filepath.Join(s.GitDir, "FETCH_HEAD"),
+	} {
+		info, err := os.Stat(p)
+		if err != nil {
+			b.WriteString("-;")
+			continue
+		}
+		fmt.Fprintf(&b, "%d:%d;", info.ModTime().UnixNano(), info.Size())
+	}
+
+	// Editing a tracked file rewrites it without touching git metadata
+	entries, err := os.ReadDir(s.Path)
+	if err != nil {
+		return b.String()
+	}
+	for _, entry := range entries {
+		if entry.Name() == ".git" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", entry.Name(), info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String()
+}
+
+// runGit runs git in dir without taking optional locks, so refreshing never
+// interferes with git commands the user is running
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return "", errors.New(strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+	return string(out), nil
+}
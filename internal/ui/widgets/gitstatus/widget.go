@@ -0,0 +1,292 @@
+// Package gitstatus provides a widget for the status of local git repositories
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	defaultInterval = 30 * time.Second
+	// defaultWatchInterval is how often git metadata and the top level of the
+	// working tree are checked for changes; it only stats files, so it can run
+	// much more often than git. Deeper edits wait for the full refresh.
+	defaultWatchInterval = 2 * time.Second
+	envRepos             = "DASHBOARD_GIT_REPOS"
+)
+
+// repoState is the latest result for one configured repository
+type repoState struct {
+	status Status
+	err    error
+}
+
+// Widget represents the git status widget
+type Widget struct {
+	components.BaseWidget
+	paths         []string
+	interval      time.Duration
+	watchInterval time.Duration
+
+	repos        []repoState
+	fingerprints []string
+	sampledAt    time.Time
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithRepos sets the repositories to show
+func WithRepos(paths ...string) Option {
+	return func(w *Widget) {
+		w.paths = paths
+	}
+}
+
+// WithInterval sets the full refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// WithWatchInterval sets how often repositories are checked for changes
+func WithWatchInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.watchInterval = interval
+	}
+}
+
+// New creates a new git status widget. Repositories default to the
+// comma-separated DASHBOARD_GIT_REPOS, where ~ expands to the home directory.
+func New(opts ...Option) *Widget {
+	var paths []string
+	for _, p := range format.SplitList(os.Getenv(envRepos)) {
+		paths = append(paths, expandHome(p))
+	}
+
+	w := &Widget{
+		paths:         paths,
+		interval:      defaultInterval,
+		watchInterval: defaultWatchInterval,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return tea.Batch(w.fetchStatus, w.watchTick())
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statusMsg:
+		w.repos = msg.repos
+		w.sampledAt = msg.sampledAt
+		w.fingerprints = fingerprints(w.repos)
+		if msg.changed {
+			// The interval refresh is already scheduled
+			return w, nil
+		}
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.fetchStatus
+	case watchMsg:
+		return w, w.checkChanges()
+	case changedMsg:
+		if msg.changed {
+			return w, tea.Batch(w.fetchChanged, w.watchTick())
+		}
+		return w, w.watchTick()
+	}
+	return w, nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	b.WriteString(styles.Title.Render(fmt.Sprintf("Repositories (%d)", len(w.paths))))
+	b.WriteString("\n\n")
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+
+	switch {
+	case len(w.paths) == 0:
+		b.WriteString(subtle.Render("No repositories configured, set " + envRepos))
+	case w.repos == nil:
+		b.WriteString(subtle.Render("Loading..."))
+	}
+
+	for _, repo := range w.repos {
+		if repo.err != nil {
+			b.WriteString(errorStyle.Render(repo.err.Error()))
+			b.WriteString("\n")
+			continue
+		}
+		w.renderRepo(&b, repo.status)
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderRepo writes the branch, change counts and last commit of a repository
+func (w *Widget) renderRepo(b *strings.Builder, s Status) {
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	branch := s.Branch
+	if s.Detached {
+		branch = lipgloss.NewStyle().Foreground(styles.Warning).Render("detached")
+	}
+	line := styles.Title.Render(s.Name()) + " ⎇ " + branch
+	if s.Ahead > 0 {
+		line += fmt.Sprintf(" ↑%d", s.Ahead)
+	}
+	if s.Behind > 0 {
+		line += lipgloss.NewStyle().Foreground(styles.Warning).Render(fmt.Sprintf(" ↓%d", s.Behind))
+	}
+	if s.Upstream == "" && !s.Detached {
+		line += subtle.Render(" no upstream")
+	}
+	b.WriteString(line)
+	b.WriteString("\n  ")
+
+	if s.Clean() {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Render("clean"))
+	} else {
+		var counts []string
+		if s.Conflicts > 0 {
+			counts = append(counts, lipgloss.NewStyle().Foreground(styles.Critical).
+				Render(fmt.Sprintf("!%d conflicts", s.Conflicts)))
+		}
+		if s.Staged > 0 {
+			counts = append(counts, fmt.Sprintf("+%d staged", s.Staged))
+		}
+		if s.Dirty > 0 {
+			counts = append(counts, lipgloss.NewStyle().Foreground(styles.Warning).
+				Render(fmt.Sprintf("~%d modified", s.Dirty)))
+		}
+		if s.Untracked > 0 {
+			counts = append(counts, fmt.Sprintf("?%d untracked", s.Untracked))
+		}
+		b.WriteString(strings.Join(counts, " "))
+	}
+	b.WriteString("\n")
+
+	if !s.CommittedAt.IsZero() {
+		b.WriteString("  " + s.Subject + " " + subtle.Render(formatAge(w.sampledAt.Sub(s.CommittedAt))))
+	} else {
+		b.WriteString(subtle.Render("  no commits"))
+	}
+	b.WriteString("\n\n")
+}
+
+// fingerprints returns the change fingerprint of each repository
+func fingerprints(repos []repoState) []string {
+	prints := make([]string, len(repos))
+	for i, repo := range repos {
+		prints[i] = fingerprint(repo.status)
+	}
+	return prints
+}
+
+// formatAge formats how long ago something happened
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours())/24)
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// Message types for the git status widget
+type statusMsg struct {
+	repos     []repoState
+	sampledAt time.Time
+	// changed is set when the refresh was triggered by a detected change
+	changed bool
+}
+
+type refreshMsg struct{}
+
+type watchMsg struct{}
+
+type changedMsg struct {
+	changed bool
+}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) watchTick() tea.Cmd {
+	return tea.Tick(w.watchInterval, func(t time.Time) tea.Msg {
+		return watchMsg{}
+	})
+}
+
+func (w *Widget) fetchStatus() tea.Msg {
+	repos := make([]repoState, len(w.paths))
+	for i, path := range w.paths {
+		status, err := readStatus(path)
+		repos[i] = repoState{status: status, err: err}
+	}
+	return statusMsg{repos: repos, sampledAt: time.Now()}
+}
+
+func (w *Widget) fetchChanged() tea.Msg {
+	msg := w.fetchStatus().(statusMsg)
+	msg.changed = true
+	return msg
+}
+
+func (w *Widget) checkChanges() tea.Cmd {
+	repos, previous := w.repos, w.fingerprints
+	return func() tea.Msg {
+		current := fingerprints(repos)
+		for i := range current {
+			if i < len(previous) && current[i] != previous[i] {
+				return changedMsg{changed: true}
+			}
+		}
+		return changedMsg{}
+	}
+}
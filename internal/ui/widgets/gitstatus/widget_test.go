@@ -0,0 +1,168 @@
+package gitstatus
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// git runs a git command in dir with a fixed identity and no user config
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_GLOBAL=/dev/null",
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), out)
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
+
+// newRepo creates a repository with one commit, cloned from a bare origin
+func newRepo(t *testing.T) (repo, origin string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	origin = filepath.Join(dir, "origin.git")
+	seed := filepath.Join(dir, "seed")
+	repo = filepath.Join(dir, "project")
+
+	git(t, dir, "init", "--bare", "-b", "main", origin)
+	git(t, dir, "clone", origin, seed)
+	writeFile(t, filepath.Join(seed, "README.md"), "hello\n")
+	git(t, seed, "add", ".")
+	git(t, seed, "commit", "-m", "Initial commit")
+	git(t, seed, "push", "origin", "HEAD:main")
+	git(t, dir, "clone", origin, repo)
+
+	return repo, seed
+}
+
+func TestReadStatus(t *testing.T) {
+	repo, seed := newRepo(t)
+
+	s, err := readStatus(repo)
+	require.NoError(t, err)
+	assert.Equal(t, "project", s.Name())
+	assert.Equal(t, "main", s.Branch)
+	assert.Equal(t, "origin/main", s.Upstream)
+	assert.True(t, s.Clean())
+	assert.Equal(t, "Initial commit", s.Subject)
+	assert.WithinDuration(t, time.Now(), s.CommittedAt, time.Minute)
+
+	// One local commit, one upstream commit, and working tree changes
+	writeFile(t, filepath.Join(repo, "local.txt"), "local\n")
+	git(t, repo, "add", "local.txt")
+	git(t, repo, "commit", "-m", "Local change")
+
+	writeFile(t, filepath.Join(seed, "remote.txt"), "remote\n")
+	git(t, seed, "add", ".")
+	git(t, seed, "commit", "-m", "Remote change")
+	git(t, seed, "push", "origin", "HEAD:main")
+	git(t, repo, "fetch")
+
+	writeFile(t, filepath.Join(repo, "README.md"), "changed\n")
+	writeFile(t, filepath.Join(repo, "staged.txt"), "staged\n")
+	git(t, repo, "add", "staged.txt")
+	writeFile(t, filepath.Join(repo, "new.txt"), "untracked\n")
+
+	s, err = readStatus(repo)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Ahead)
+	assert.Equal(t, 1, s.Behind)
+	assert.Equal(t, 1, s.Staged)
+	assert.Equal(t, 1, s.Dirty)
+	assert.Equal(t, 1, s.Untracked)
+	assert.Equal(t, "Local change", s.Subject)
+
+	t.Run("not a repository", func(t *testing.T) {
+		_, err := readStatus(t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a git repository")
+	})
+
+	t.Run("empty repository", func(t *testing.T) {
+		dir := t.TempDir()
+		git(t, dir, "init", "-b", "main")
+		s, err := readStatus(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "main", s.Branch)
+		assert.True(t, s.CommittedAt.IsZero())
+	})
+}
+
+func TestGitStatusWidget(t *testing.T) {
+	repo, _ := newRepo(t)
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	t.Run("env repos", func(t *testing.T) {
+		home, err := os.UserHomeDir()
+		require.NoError(t, err)
+		t.Setenv(envRepos, "~/src/app, /srv/site")
+		assert.Equal(t, []string{filepath.Join(home, "src/app"), "/srv/site"}, New().paths)
+	})
+
+	w := New(WithRepos(repo, missing))
+	w.SetSize(80, 30)
+	assert.Contains(t, w.View(), "Loading...")
+
+	_, cmd := w.Update(w.fetchStatus())
+	assert.NotNil(t, cmd, "expected next tick")
+
+	view := w.View()
+	assert.Contains(t, view, "project ⎇ main")
+	assert.Contains(t, view, "clean")
+	assert.Contains(t, view, "Initial commit just now")
+	assert.Contains(t, view, "not a git repository")
+
+	t.Run("refreshes on change", func(t *testing.T) {
+		msg := w.checkChanges()()
+		assert.Equal(t, changedMsg{}, msg)
+
+		writeFile(t, filepath.Join(repo, "staged.txt"), "staged\n")
+		git(t, repo, "add", "staged.txt")
+
+		msg = w.checkChanges()()
+		assert.Equal(t, changedMsg{changed: true}, msg)
+
+		_, cmd := w.Update(w.fetchChanged())
+		assert.Nil(t, cmd, "interval refresh is already scheduled")
+		assert.Contains(t, w.View(), "+1 staged")
+	})
+
+	t.Run("refreshes on working tree edit", func(t *testing.T) {
+		assert.Equal(t, changedMsg{}, w.checkChanges()())
+
+		readme := filepath.Join(repo, "README.md")
+		writeFile(t, readme, "hello again\n")
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(readme, later, later))
+
+		assert.Equal(t, changedMsg{changed: true}, w.checkChanges()())
+		w.Update(w.fetchChanged())
+		assert.Contains(t, w.View(), "~1 modified")
+	})
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "just now", formatAge(30*time.Second))
+	assert.Equal(t, "5m ago", formatAge(5*time.Minute))
+	assert.Equal(t, "3h ago", formatAge(3*time.Hour))
+	assert.Equal(t, "2d ago", formatAge(50*time.Hour))
+}
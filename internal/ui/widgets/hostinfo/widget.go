@@ -0,0 +1,221 @@
+// Package hostinfo provides a widget for host details, uptime, load and sessions
+package hostinfo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+)
+
+// defaultInterval is slower than the CPU bar since host details change rarely
+const defaultInterval = 30 * time.Second
+
+// Widget represents the host overview widget
+type Widget struct {
+	components.BaseWidget
+	interval time.Duration
+
+	info      *host.InfoStat
+	load      *load.AvgStat
+	cores     int
+	users     []host.UserStat
+	sampledAt time.Time
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithInterval sets the refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new host overview widget
+func New(opts ...Option) *Widget {
+	w := &Widget{
+		interval: defaultInterval,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.sample
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case hostMsg:
+		w.lastError = msg.err
+		if msg.err == nil {
+			w.info = msg.info
+			w.load = msg.load
+			w.cores = msg.cores
+			w.users = msg.users
+			w.sampledAt = msg.sampledAt
+		}
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.sample
+	}
+	return w, nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	b.WriteString(styles.Title.Render("Host"))
+	b.WriteString("\n\n")
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	if w.lastError != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+		return w.GetStyle().Width(width).Height(height).Render(b.String())
+	}
+	if w.info == nil {
+		b.WriteString(subtle.Render("Loading..."))
+		return w.GetStyle().Width(width).Height(height).Render(b.String())
+	}
+
+	b.WriteString(styles.Title.Render(w.info.Hostname))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s\n", w.info.Platform, w.info.PlatformVersion))
+	b.WriteString(subtle.Render(fmt.Sprintf("%s %s %s", w.info.OS, w.info.KernelVersion, w.info.KernelArch)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("up %s\n", formatUptime(time.Duration(w.info.Uptime)*time.Second)))
+
+	if w.load != nil {
+		b.WriteString("\n")
+		b.WriteString(styles.Title.Render("Load"))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("%s %s %s", w.renderLoad(w.load.Load1),
+			w.renderLoad(w.load.Load5), w.renderLoad(w.load.Load15)))
+		b.WriteString("\n")
+		b.WriteString(subtle.Render(fmt.Sprintf("1/5/15 min, %d cores", w.cores)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.Title.Render(fmt.Sprintf("Sessions (%d)", len(w.users))))
+	b.WriteString("\n")
+	for _, u := range w.users {
+		line := fmt.Sprintf("%s %s", u.User, u.Terminal)
+		if u.Host != "" {
+			line += " from " + u.Host
+		}
+		if u.Started > 0 {
+			started := time.Unix(int64(u.Started), 0)
+			line += " " + subtle.Render(formatUptime(w.sampledAt.Sub(started)))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderLoad formats a load average relative to the core count, highlighting
+// values at or above the number of cores
+func (w *Widget) renderLoad(avg float64) string {
+	text := fmt.Sprintf("%.2f", avg)
+	if w.cores <= 0 {
+		return text
+	}
+
+	ratio := avg / float64(w.cores)
+	text += fmt.Sprintf(" (%.0f%%)", ratio*100)
+	switch {
+	case ratio >= 2:
+		return lipgloss.NewStyle().Foreground(styles.Critical).Render(text)
+	case ratio >= 1:
+		return lipgloss.NewStyle().Foreground(styles.Warning).Render(text)
+	}
+	return text
+}
+
+// formatUptime formats a duration as days, hours and minutes
+func formatUptime(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// Message types for the host widget
+type hostMsg struct {
+	info      *host.InfoStat
+	load      *load.AvgStat
+	cores     int
+	users     []host.UserStat
+	sampledAt time.Time
+	err       error
+}
+
+type refreshMsg struct{}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) sample() tea.Msg {
+	info, err := host.Info()
+	if err != nil {
+		return hostMsg{err: fmt.Errorf("failed to read host info: %w", err)}
+	}
+
+	// Load, core count and sessions are optional; not every platform has them
+	avg, err := load.Avg()
+	if err != nil {
+		avg = nil
+	}
+	cores, err := cpu.Counts(true)
+	if err != nil {
+		cores = 0
+	}
+	users, err := host.Users()
+	if err != nil {
+		users = nil
+	}
+
+	return hostMsg{
+		info:      info,
+		load:      avg,
+		cores:     cores,
+		users:     users,
+		sampledAt: time.Now(),
+	}
+}
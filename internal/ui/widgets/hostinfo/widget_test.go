@@ -0,0 +1,66 @@
+package hostinfo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostWidget(t *testing.T) {
+	now := time.Now()
+
+	t.Run("renders host overview", func(t *testing.T) {
+		w := New()
+		w.SetSize(80, 40)
+		assert.Contains(t, w.View(), "Loading...")
+
+		_, cmd := w.Update(hostMsg{
+			info: &host.InfoStat{
+				Hostname:        "devbox",
+				Uptime:          uint64((26*time.Hour + 5*time.Minute).Seconds()),
+				OS:              "linux",
+				Platform:        "ubuntu",
+				PlatformVersion: "24.04",
+				KernelVersion:   "6.8.0",
+				KernelArch:      "x86_64",
+			},
+			load:  &load.AvgStat{Load1: 4.5, Load5: 2.0, Load15: 1.0},
+			cores: 4,
+			users: []host.UserStat{
+				{User: "dev", Terminal: "pts/0", Host: "10.0.0.5", Started: int(now.Add(-90 * time.Minute).Unix())},
+			},
+			sampledAt: now,
+		})
+		assert.NotNil(t, cmd, "expected next tick")
+
+		view := w.View()
+		assert.Contains(t, view, "devbox")
+		assert.Contains(t, view, "ubuntu 24.04")
+		assert.Contains(t, view, "linux 6.8.0 x86_64")
+		assert.Contains(t, view, "up 1d 2h 5m")
+		assert.Contains(t, view, "4.50 (112%)")
+		assert.Contains(t, view, "2.00 (50%)")
+		assert.Contains(t, view, "4 cores")
+		assert.Contains(t, view, "Sessions (1)")
+		assert.Contains(t, view, "dev pts/0 from 10.0.0.5")
+		assert.Contains(t, view, "1h 30m")
+	})
+
+	t.Run("error state", func(t *testing.T) {
+		w := New()
+		w.SetSize(80, 40)
+		w.Update(hostMsg{err: errors.New("host info unavailable")})
+		assert.Contains(t, w.View(), "host info unavailable")
+	})
+}
+
+func TestFormatUptime(t *testing.T) {
+	assert.Equal(t, "0m", formatUptime(-time.Minute))
+	assert.Equal(t, "45m", formatUptime(45*time.Minute))
+	assert.Equal(t, "3h 0m", formatUptime(3*time.Hour))
+	assert.Equal(t, "2d 1h 1m", formatUptime(49*time.Hour+time.Minute))
+}
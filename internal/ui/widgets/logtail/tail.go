@@ -0,0 +1,142 @@
+package logtail
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// initialBacklog is how much of an existing file is read when tailing starts
+const initialBacklog = 64 * 1024
+
+// tailer follows a single file across rotation. Lumberjack rotates by renaming
+// the file and creating a new one at the same path, so a change of file
+// identity at the path means the old file is drained and the new one read
+// from the start. A file that shrinks was truncated and is re-read as well.
+type tailer struct {
+	path string
+
+	file    *os.File
+	info    os.FileInfo
+	offset  int64
+	partial string
+	// skipFirst drops the first line, which was cut by seeking into the file
+	skipFirst bool
+}
+
+// newTailer creates a tailer for path; the file does not need to exist yet
+func newTailer(path string) *tailer {
+	return &tailer{path: path}
+}
+
+// poll returns the complete lines written since the previous call
+func (t *tailer) poll() ([]string, error) {
+	if t.file == nil {
+		// The first open starts near the end; later opens follow a rotation
+		if err := t.open(t.info == nil); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	lines, err := t.read()
+	if err != nil {
+		return lines, err
+	}
+
+	current, err := os.Stat(t.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Mid-rotation; the new file will be picked up on the next poll
+			return lines, nil
+		}
+		return lines, fmt.Errorf("failed to stat %s: %w", t.path, err)
+	}
+
+	switch {
+	case !os.SameFile(t.info, current):
+		t.close()
+		if err := t.open(false); err != nil {
+			return lines, err
+		}
+		more, err := t.read()
+		return append(lines, more...), err
+	case current.Size() < t.offset:
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return lines, fmt.Errorf("failed to rewind %s: %w", t.path, err)
+		}
+		t.offset = 0
+		t.partial = ""
+		more, err := t.read()
+		return append(lines, more...), err
+	}
+	return lines, nil
+}
+
+// open opens the file at path, seeking to the last initialBacklog bytes when
+// fromEnd is set
+func (t *tailer) open(fromEnd bool) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat %s: %w", t.path, err)
+	}
+
+	t.file = f
+	t.info = info
+	t.offset = 0
+	t.partial = ""
+	t.skipFirst = false
+
+	if fromEnd && info.Size() > initialBacklog {
+		offset, err := f.Seek(info.Size()-initialBacklog, io.SeekStart)
+		if err != nil {
+			t.close()
+			return fmt.Errorf("failed to seek %s: %w", t.path, err)
+		}
+		t.offset = offset
+		t.skipFirst = true
+	}
+	return nil
+}
+
+// read returns the complete lines between the current offset and EOF,
+// keeping any unterminated line for the next call
+func (t *tailer) read() ([]string, error) {
+	data, err := io.ReadAll(t.file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", t.path, err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	t.offset += int64(len(data))
+
+	parts := strings.Split(t.partial+string(data), "\n")
+	t.partial = parts[len(parts)-1]
+	lines := parts[:len(parts)-1]
+	if t.skipFirst && len(lines) > 0 {
+		lines = lines[1:]
+		t.skipFirst = false
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines, nil
+}
+
+// close releases the open file
+func (t *tailer) close() {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
@@ -0,0 +1,408 @@
+// Package logtail provides a widget for following log files
+package logtail
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	defaultInterval = time.Second
+	defaultFile     = "logs/app.log"
+	envFiles        = "DASHBOARD_LOGTAIL_FILES"
+	// maxLines is the number of lines kept in memory across all files
+	maxLines = 1000
+	// chromeHeight is the number of lines used by borders, padding, title,
+	// filter, status and help text
+	chromeHeight = 8
+)
+
+// logLine is a single line read from a file
+type logLine struct {
+	source string
+	raw    string
+	// level and text are set for JSON zap entries
+	level string
+	text  string
+}
+
+// Widget represents the log tail widget
+type Widget struct {
+	components.BaseWidget
+	files    []string
+	interval time.Duration
+	tailers  []*tailer
+
+	lines []logLine
+
+	filter      textinput.Model
+	filtering   bool
+	pattern     *regexp.Regexp
+	filterError error
+
+	// following keeps the view at the newest line; scrolling up pauses it
+	following bool
+	top       int
+
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithFiles sets the files to follow
+func WithFiles(files ...string) Option {
+	return func(w *Widget) {
+		w.files = files
+	}
+}
+
+// WithInterval sets how often files are polled
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new log tail widget. Files default to the comma-separated
+// DASHBOARD_LOGTAIL_FILES, then the dashboard's own log.
+func New(opts ...Option) *Widget {
+	files := format.SplitList(os.Getenv(envFiles))
+	if files == nil {
+		files = []string{defaultFile}
+	}
+
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "regex"
+
+	w := &Widget{
+		files:     files,
+		interval:  defaultInterval,
+		filter:    filter,
+		following: true,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	for _, f := range w.files {
+		w.tailers = append(w.tailers, newTailer(f))
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.poll
+}
+
+// CapturingInput implements components.InputCapturer
+func (w *Widget) CapturingInput() bool {
+	return w.filtering
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		return w, w.handleKey(msg)
+	case linesMsg:
+		w.lastError = msg.err
+		w.appendLines(msg.lines)
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.poll
+	}
+	return w, nil
+}
+
+// handleKey processes a key press while focused
+func (w *Widget) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if w.filtering {
+		switch msg.String() {
+		case "esc":
+			w.filter.SetValue("")
+			w.stopFiltering()
+		case "enter":
+			w.stopFiltering()
+		default:
+			var cmd tea.Cmd
+			w.filter, cmd = w.filter.Update(msg)
+			w.compileFilter()
+			return cmd
+		}
+		w.compileFilter()
+		return nil
+	}
+
+	page := w.pageSize()
+	switch msg.String() {
+	case "up", "k":
+		w.scroll(-1)
+	case "down", "j":
+		w.scroll(1)
+	case "pgup":
+		w.scroll(-page)
+	case "pgdown":
+		w.scroll(page)
+	case "G", "end":
+		w.following = true
+	case "/":
+		w.filtering = true
+		return w.filter.Focus()
+	case "esc":
+		w.filter.SetValue("")
+		w.compileFilter()
+	}
+	return nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	title := "Logs"
+	if len(w.files) == 1 {
+		title += " " + filepath.Base(w.files[0])
+	}
+	if !w.following {
+		title += " (paused)"
+	}
+	b.WriteString(styles.Title.Render(title))
+	b.WriteString("\n")
+
+	if w.filtering || w.filter.Value() != "" {
+		b.WriteString(w.filter.View())
+		if w.filterError != nil {
+			b.WriteString(" ")
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")).Render(w.filterError.Error()))
+		}
+	}
+	b.WriteString("\n")
+
+	visible := w.visible()
+	rows := w.pageSize()
+	if w.following {
+		w.top = max(len(visible)-rows, 0)
+	}
+	w.top = max(min(w.top, len(visible)-rows), 0)
+
+	if len(visible) == 0 {
+		b.WriteString(subtle.Render("No log lines"))
+		b.WriteString("\n")
+	}
+	end := min(w.top+rows, len(visible))
+	for _, line := range visible[w.top:end] {
+		b.WriteString(w.renderLine(line))
+		b.WriteString("\n")
+	}
+
+	if w.lastError != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("↑/↓: scroll • G: follow • /: filter"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderLine formats a line, coloring zap entries by level
+func (w *Widget) renderLine(line logLine) string {
+	var prefix string
+	if len(w.files) > 1 {
+		prefix = lipgloss.NewStyle().Foreground(styles.Subtle).Render(filepath.Base(line.source)) + " "
+	}
+	if line.level == "" {
+		return prefix + line.raw
+	}
+	levelStyle := lipgloss.NewStyle().Foreground(levelColor(line.level)).Bold(true)
+	return prefix + levelStyle.Render(fmt.Sprintf("%-5s", line.level)) + " " + line.text
+}
+
+// appendLines adds new lines, dropping the oldest beyond maxLines
+func (w *Widget) appendLines(lines []logLine) {
+	w.lines = append(w.lines, lines...)
+	if dropped := len(w.lines) - maxLines; dropped > 0 {
+		w.lines = append([]logLine(nil), w.lines[dropped:]...)
+		if !w.following {
+			// Keep the paused view on the same lines; approximate when filtered
+			w.top = max(w.top-dropped, 0)
+		}
+	}
+}
+
+// visible returns the lines matching the filter
+func (w *Widget) visible() []logLine {
+	if w.pattern == nil {
+		return w.lines
+	}
+	var matched []logLine
+	for _, line := range w.lines {
+		if w.pattern.MatchString(line.raw) {
+			matched = append(matched, line)
+		}
+	}
+	return matched
+}
+
+// scroll moves the view by delta lines; scrolling up pauses following and
+// reaching the bottom resumes it
+func (w *Widget) scroll(delta int) {
+	total := len(w.visible())
+	rows := w.pageSize()
+	bottom := max(total-rows, 0)
+	if w.following {
+		w.top = bottom
+	}
+
+	w.top = max(min(w.top+delta, bottom), 0)
+	w.following = w.top >= bottom && delta > 0
+	if total <= rows {
+		w.following = true
+	}
+}
+
+// pageSize returns the number of log lines that fit in the widget
+func (w *Widget) pageSize() int {
+	_, height := w.GetDimensions()
+	return max(height-chromeHeight, 1)
+}
+
+// compileFilter updates the filter regex, keeping the previous one on error
+func (w *Widget) compileFilter() {
+	value := w.filter.Value()
+	if value == "" {
+		w.pattern = nil
+		w.filterError = nil
+		return
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		w.filterError = fmt.Errorf("invalid regex")
+		return
+	}
+	w.pattern = re
+	w.filterError = nil
+}
+
+// stopFiltering leaves filter input mode
+func (w *Widget) stopFiltering() {
+	w.filtering = false
+	w.filter.Blur()
+}
+
+// parseLine decodes zap JSON entries; other lines are kept as-is
+func parseLine(source, raw string) logLine {
+	line := logLine{source: source, raw: raw}
+	if !strings.HasPrefix(raw, "{") {
+		return line
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return line
+	}
+	level, ok := entry["level"].(string)
+	if !ok {
+		return line
+	}
+	line.level = strings.ToUpper(level)
+
+	var b strings.Builder
+	if ts, ok := entry["timestamp"].(string); ok {
+		if t, err := time.Parse("2006-01-02T15:04:05.000Z0700", ts); err == nil {
+			b.WriteString(t.Format("15:04:05") + " ")
+		}
+	}
+	if msg, ok := entry["msg"].(string); ok {
+		b.WriteString(msg)
+	}
+
+	// Remaining fields in a stable order
+	keys := make([]string, 0, len(entry))
+	for k := range entry {
+		switch k {
+		case "level", "timestamp", "msg", "caller", "logger", "stacktrace":
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(fmt.Sprintf(" %s=%v", k, entry[k]))
+	}
+
+	line.text = b.String()
+	return line
+}
+
+// levelColor returns the display color of a zap level
+func levelColor(level string) lipgloss.Color {
+	switch level {
+	case "DEBUG":
+		return styles.Subtle
+	case "WARN":
+		return styles.Warning
+	case "ERROR", "DPANIC", "PANIC", "FATAL":
+		return styles.Critical
+	}
+	return styles.Primary
+}
+
+// Message types for the log tail widget
+type linesMsg struct {
+	lines []logLine
+	err   error
+}
+
+type refreshMsg struct{}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) poll() tea.Msg {
+	var msg linesMsg
+	for _, t := range w.tailers {
+		lines, err := t.poll()
+		for _, raw := range lines {
+			msg.lines = append(msg.lines, parseLine(t.path, raw))
+		}
+		if err != nil {
+			msg.err = err
+		}
+	}
+	return msg
+}
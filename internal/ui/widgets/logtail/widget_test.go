@@ -0,0 +1,151 @@
+package logtail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestTailer(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	tl := newTailer(path)
+	t.Cleanup(tl.close)
+
+	lines, err := tl.poll()
+	require.NoError(t, err)
+	assert.Empty(t, lines, "missing file is not an error")
+
+	appendFile(t, path, "one\ntwo\nthr")
+	lines, err = tl.poll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, lines)
+
+	appendFile(t, path, "ee\n")
+	lines, _ = tl.poll()
+	assert.Equal(t, []string{"three"}, lines, "partial line completed")
+
+	t.Run("follows lumberjack rotation", func(t *testing.T) {
+		appendFile(t, path, "last before rotate\n")
+		require.NoError(t, os.Rename(path, filepath.Join(dir, "app-2024-01-15T10-30-00.000.log")))
+		appendFile(t, path, "first after rotate\n")
+
+		lines, err := tl.poll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"last before rotate", "first after rotate"}, lines)
+	})
+
+	t.Run("follows truncation", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("new\n"), 0o644))
+		lines, err := tl.poll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new"}, lines)
+	})
+
+	t.Run("starts near the end of large files", func(t *testing.T) {
+		big := filepath.Join(dir, "big.log")
+		var b strings.Builder
+		for i := 0; b.Len() < 2*initialBacklog; i++ {
+			fmt.Fprintf(&b, "line %d\n", i)
+		}
+		require.NoError(t, os.WriteFile(big, []byte(b.String()), 0o644))
+
+		tl := newTailer(big)
+		t.Cleanup(tl.close)
+		lines, err := tl.poll()
+		require.NoError(t, err)
+		assert.Less(t, len(lines), strings.Count(b.String(), "\n"))
+		assert.True(t, strings.HasPrefix(lines[0], "line "), "first line is complete: %q", lines[0])
+	})
+}
+
+func TestParseLine(t *testing.T) {
+	line := parseLine("app.log", `{"level":"WARN","timestamp":"2024-01-15T10:30:00.000Z","caller":"ui/dashboard.go:10","msg":"Unknown widget","name":"foo"}`)
+	assert.Equal(t, "WARN", line.level)
+	assert.Equal(t, "10:30:00 Unknown widget name=foo", line.text)
+
+	plain := parseLine("syslog", "Jan 15 kernel: hello")
+	assert.Empty(t, plain.level)
+
+	assert.Equal(t, styles.Critical, levelColor("ERROR"))
+	assert.Equal(t, styles.Warning, levelColor("WARN"))
+}
+
+func TestLogTailWidget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	t.Run("env files", func(t *testing.T) {
+		t.Setenv(envFiles, "a.log, b.log")
+		assert.Equal(t, []string{"a.log", "b.log"}, New().files)
+		t.Setenv(envFiles, "")
+		assert.Equal(t, []string{defaultFile}, New().files)
+	})
+
+	w := New(WithFiles(path))
+	w.SetSize(80, chromeHeight+5)
+	w.Focus()
+
+	for i := 0; i < 20; i++ {
+		appendFile(t, path, fmt.Sprintf(`{"level":"INFO","msg":"event %d"}`+"\n", i))
+	}
+	appendFile(t, path, "plain text line\n")
+	_, cmd := w.Update(w.poll())
+	assert.NotNil(t, cmd, "expected next tick")
+
+	view := w.View()
+	assert.Contains(t, view, "plain text line")
+	assert.Contains(t, view, "INFO  event 19")
+	assert.NotContains(t, view, "event 10")
+
+	t.Run("scrolling up pauses following", func(t *testing.T) {
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+		assert.Contains(t, w.View(), "(paused)")
+		assert.NotContains(t, w.View(), "plain text line")
+
+		appendFile(t, path, "arrived while paused\n")
+		w.Update(w.poll())
+		assert.NotContains(t, w.View(), "arrived while paused")
+
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+		assert.NotContains(t, w.View(), "(paused)")
+		assert.Contains(t, w.View(), "arrived while paused")
+	})
+
+	t.Run("regex filter", func(t *testing.T) {
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+		assert.True(t, w.CapturingInput())
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("event [0-2]\"")})
+		w.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.False(t, w.CapturingInput())
+
+		view := w.View()
+		assert.Contains(t, view, "event 0")
+		assert.Contains(t, view, "event 2")
+		assert.NotContains(t, view, "event 3")
+		assert.NotContains(t, view, "plain text line")
+
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("(")})
+		assert.Contains(t, w.View(), "invalid regex")
+		w.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.Contains(t, w.View(), "plain text line")
+	})
+}
@@ -0,0 +1,111 @@
+package network
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// interfaceStat holds the display state of a single network interface
+type interfaceStat struct {
+	name      string
+	up        bool
+	addrs     []string
+	rxRate    float64 // bytes per second
+	txRate    float64 // bytes per second
+	rxTotal   uint64
+	txTotal   uint64
+	rxErrors  uint64
+	txErrors  uint64
+	rxDropped uint64
+	txDropped uint64
+}
+
+// Filter selects interfaces by name using glob patterns (see filepath.Match).
+// An empty Include list matches every interface; Exclude always wins.
+type Filter struct {
+	Include []string
+	Exclude []string
+}
+
+// Matches reports whether the interface name passes the filter
+func (f Filter) Matches(name string) bool {
+	if matchAny(f.Exclude, name) {
+		return false
+	}
+	return len(f.Include) == 0 || matchAny(f.Include, name)
+}
+
+// matchAny reports whether name matches any of the patterns
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, err := filepath.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// computeStats merges counters and interface details into per-interface stats.
+// Rates are derived from prev; interfaces without a previous sample report zero.
+func computeStats(
+	prev, cur map[string]net.IOCountersStat,
+	ifaces []net.InterfaceStat,
+	elapsed time.Duration,
+	filter Filter,
+) []interfaceStat {
+	details := make(map[string]net.InterfaceStat, len(ifaces))
+	for _, iface := range ifaces {
+		details[iface.Name] = iface
+	}
+
+	secs := elapsed.Seconds()
+	stats := make([]interfaceStat, 0, len(cur))
+	for name, c := range cur {
+		if !filter.Matches(name) {
+			continue
+		}
+
+		stat := interfaceStat{
+			name:      name,
+			rxTotal:   c.BytesRecv,
+			txTotal:   c.BytesSent,
+			rxErrors:  c.Errin,
+			txErrors:  c.Errout,
+			rxDropped: c.Dropin,
+			txDropped: c.Dropout,
+		}
+
+		if p, ok := prev[name]; ok && secs > 0 {
+			stat.rxRate = float64(format.CounterDelta(p.BytesRecv, c.BytesRecv)) / secs
+			stat.txRate = float64(format.CounterDelta(p.BytesSent, c.BytesSent)) / secs
+		}
+
+		if iface, ok := details[name]; ok {
+			stat.up = hasFlag(iface.Flags, "up")
+			for _, addr := range iface.Addrs {
+				stat.addrs = append(stat.addrs, addr.Addr)
+			}
+		}
+
+		stats = append(stats, stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].name < stats[j].name
+	})
+	return stats
+}
+
+// hasFlag reports whether flags contains flag
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
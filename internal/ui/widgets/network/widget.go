@@ -0,0 +1,196 @@
+// Package network provides a widget for displaying network interface throughput
+package network
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// Default configuration
+const (
+	defaultInterval = 2 * time.Second
+	envInclude      = "DASHBOARD_NET_INCLUDE"
+	envExclude      = "DASHBOARD_NET_EXCLUDE"
+)
+
+// defaultExclude hides the loopback interface unless configured otherwise
+var defaultExclude = []string{"lo"}
+
+// Widget represents the network interface widget
+type Widget struct {
+	components.BaseWidget
+	filter   Filter
+	interval time.Duration
+
+	prev       map[string]net.IOCountersStat
+	prevAt     time.Time
+	interfaces []interfaceStat
+	lastError  error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithFilter sets the interface include/exclude filter
+func WithFilter(filter Filter) Option {
+	return func(w *Widget) {
+		w.filter = filter
+	}
+}
+
+// WithInterval sets how often counters are sampled
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new network widget. The interface filter defaults to the
+// comma-separated DASHBOARD_NET_INCLUDE and DASHBOARD_NET_EXCLUDE patterns.
+func New(opts ...Option) *Widget {
+	filter := Filter{
+		Include: format.SplitList(os.Getenv(envInclude)),
+		Exclude: format.SplitList(os.Getenv(envExclude)),
+	}
+	if filter.Exclude == nil {
+		filter.Exclude = defaultExclude
+	}
+
+	w := &Widget{
+		filter:   filter,
+		interval: defaultInterval,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.sample
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case networkMsg:
+		w.lastError = msg.err
+		if msg.err == nil {
+			w.interfaces = computeStats(w.prev, msg.counters, msg.interfaces,
+				msg.sampledAt.Sub(w.prevAt), w.filter)
+			w.prev = msg.counters
+			w.prevAt = msg.sampledAt
+		}
+		return w, w.tick()
+	case updateNetworkMsg:
+		return w, w.sample
+	}
+	return w, nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	b.WriteString(styles.Title.Render("Network"))
+	b.WriteString("\n")
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	if w.lastError != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+		return w.GetStyle().Width(width).Height(height).Render(b.String())
+	}
+
+	if len(w.interfaces) == 0 {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("No interfaces"))
+		return w.GetStyle().Width(width).Height(height).Render(b.String())
+	}
+
+	up := lipgloss.NewStyle().Foreground(styles.Primary)
+	down := lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	for _, iface := range w.interfaces {
+		state := down.Render("down")
+		if iface.up {
+			state = up.Render("up")
+		}
+
+		b.WriteString("\n")
+		b.WriteString(styles.Title.Render(iface.name))
+		b.WriteString(" " + state + "\n")
+		b.WriteString(fmt.Sprintf("  RX %s  TX %s\n", format.Rate(iface.rxRate), format.Rate(iface.txRate)))
+		b.WriteString(subtle.Render(fmt.Sprintf("  total %s / %s  err %d/%d  drop %d/%d",
+			format.Bytes(iface.rxTotal), format.Bytes(iface.txTotal),
+			iface.rxErrors, iface.txErrors,
+			iface.rxDropped, iface.txDropped,
+		)))
+		b.WriteString("\n")
+		if len(iface.addrs) > 0 {
+			b.WriteString(subtle.Render("  " + strings.Join(iface.addrs, ", ")))
+			b.WriteString("\n")
+		}
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// networkMsg carries a new counters sample
+type networkMsg struct {
+	counters   map[string]net.IOCountersStat
+	interfaces []net.InterfaceStat
+	sampledAt  time.Time
+	err        error
+}
+
+// updateNetworkMsg triggers a new sample
+type updateNetworkMsg struct{}
+
+// tick returns a command that waits for the update interval
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return updateNetworkMsg{}
+	})
+}
+
+// sample reads per-interface counters and interface details
+func (w *Widget) sample() tea.Msg {
+	counters, err := net.IOCounters(true)
+	if err != nil {
+		return networkMsg{err: fmt.Errorf("failed to read network counters: %w", err)}
+	}
+
+	// Interface details are optional; counters alone are still useful
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		ifaces = nil
+	}
+
+	byName := make(map[string]net.IOCountersStat, len(counters))
+	for _, c := range counters {
+		byName[c.Name] = c
+	}
+
+	return networkMsg{
+		counters:   byName,
+		interfaces: ifaces,
+		sampledAt:  time.Now(),
+	}
+}
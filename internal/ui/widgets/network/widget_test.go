@@ -0,0 +1,100 @@
+package network
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   Filter
+		iface    string
+		expected bool
+	}{
+		{"empty filter matches all", Filter{}, "eth0", true},
+		{"excluded", Filter{Exclude: []string{"lo"}}, "lo", false},
+		{"glob include", Filter{Include: []string{"eth*"}}, "eth1", true},
+		{"not included", Filter{Include: []string{"eth*"}}, "wlan0", false},
+		{"exclude wins", Filter{Include: []string{"*"}, Exclude: []string{"docker*"}}, "docker0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Matches(tt.iface))
+		})
+	}
+}
+
+func TestNetworkWidget(t *testing.T) {
+	now := time.Now()
+	ifaces := []net.InterfaceStat{
+		{Name: "eth0", Flags: []string{"up", "broadcast"}, Addrs: []net.InterfaceAddr{{Addr: "10.0.0.2/24"}}},
+		{Name: "wlan0", Flags: []string{"broadcast"}},
+	}
+	first := networkMsg{
+		counters: map[string]net.IOCountersStat{
+			"eth0":  {Name: "eth0", BytesRecv: 1024, BytesSent: 2048},
+			"lo":    {Name: "lo", BytesRecv: 99},
+			"wlan0": {Name: "wlan0"},
+		},
+		interfaces: ifaces,
+		sampledAt:  now,
+	}
+	second := networkMsg{
+		counters: map[string]net.IOCountersStat{
+			"eth0":  {Name: "eth0", BytesRecv: 1024 + 4096, BytesSent: 2048 + 2048, Errin: 3, Dropout: 1},
+			"lo":    {Name: "lo", BytesRecv: 199},
+			"wlan0": {Name: "wlan0"},
+		},
+		interfaces: ifaces,
+		sampledAt:  now.Add(2 * time.Second),
+	}
+
+	t.Run("computes rates between samples", func(t *testing.T) {
+		w := New(WithFilter(Filter{Exclude: []string{"lo"}}))
+		w.SetSize(80, 30)
+
+		_, cmd := w.Update(first)
+		assert.NotNil(t, cmd, "expected next tick")
+		require.Len(t, w.interfaces, 2)
+		assert.Zero(t, w.interfaces[0].rxRate)
+
+		w.Update(second)
+		require.Len(t, w.interfaces, 2)
+		eth0 := w.interfaces[0]
+		assert.Equal(t, "eth0", eth0.name)
+		assert.True(t, eth0.up)
+		assert.InDelta(t, 2048, eth0.rxRate, 0.01)
+		assert.InDelta(t, 1024, eth0.txRate, 0.01)
+		assert.Equal(t, uint64(3), eth0.rxErrors)
+		assert.False(t, w.interfaces[1].up)
+
+		view := w.View()
+		assert.Contains(t, view, "eth0")
+		assert.Contains(t, view, "RX 2.0 KiB/s")
+		assert.Contains(t, view, "10.0.0.2/24")
+		assert.Contains(t, view, "down")
+		assert.NotContains(t, view, "lo ")
+	})
+
+	t.Run("error state", func(t *testing.T) {
+		w := New()
+		w.SetSize(80, 30)
+		w.Update(networkMsg{err: errors.New("counters unavailable")})
+		assert.Contains(t, w.View(), "counters unavailable")
+	})
+
+	t.Run("env filter", func(t *testing.T) {
+		t.Setenv(envInclude, "eth*, wlan0")
+		t.Setenv(envExclude, "")
+		w := New()
+		assert.Equal(t, []string{"eth*", "wlan0"}, w.filter.Include)
+		assert.Equal(t, defaultExclude, w.filter.Exclude)
+	})
+}
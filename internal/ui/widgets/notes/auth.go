@@ -0,0 +1,159 @@
+package notes
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Authentication configuration
+const (
+	envGodoAPIToken     = "GODO_API_TOKEN"
+	envGodoAPITokenFile = "GODO_API_TOKEN_FILE"
+
+	// redacted replaces credentials in logged text
+	redacted = "[REDACTED]"
+)
+
+// credentials produces the Authorization header for a request, along with
+// the secrets it contains so they can be redacted from logs
+type credentials func() (header string, secrets []string, err error)
+
+// WithToken authenticates requests with a static bearer token
+func WithToken(token string) ClientOption {
+	return func(c *Client) {
+		c.credentials = bearer(func() (string, error) { return token, nil })
+	}
+}
+
+// WithTokenFile authenticates requests with a bearer token read from path
+// on every request, so rotated tokens are picked up
+func WithTokenFile(path string) ClientOption {
+	return func(c *Client) {
+		c.credentials = tokenFile(path)
+	}
+}
+
+// WithTokenEnv authenticates requests with a bearer token read from the
+// environment variable key
+func WithTokenEnv(key string) ClientOption {
+	return func(c *Client) {
+		c.credentials = tokenEnv(key)
+	}
+}
+
+// WithBasicAuth authenticates requests with a username and password
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *Client) {
+		c.credentials = func() (string, []string, error) {
+			encoded := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+			return "Basic " + encoded, []string{encoded, password}, nil
+		}
+	}
+}
+
+// bearer builds credentials from a token source, rejecting empty tokens
+func bearer(token func() (string, error)) credentials {
+	return func() (string, []string, error) {
+		t, err := token()
+		if err != nil {
+			return "", nil, err
+		}
+		if t = strings.TrimSpace(t); t == "" {
+			return "", nil, errors.New("token is empty")
+		}
+		return "Bearer " + t, []string{t}, nil
+	}
+}
+
+// tokenFile returns bearer credentials read from a file
+func tokenFile(path string) credentials {
+	return bearer(func() (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		return string(data), nil
+	})
+}
+
+// tokenEnv returns bearer credentials read from an environment variable
+func tokenEnv(key string) credentials {
+	return bearer(func() (string, error) {
+		return os.Getenv(key), nil
+	})
+}
+
+// credentialsFromEnv returns credentials configured by GODO_API_TOKEN or
+// GODO_API_TOKEN_FILE, or nil
+func credentialsFromEnv() credentials {
+	switch {
+	case os.Getenv(envGodoAPIToken) != "":
+		return tokenEnv(envGodoAPIToken)
+	case os.Getenv(envGodoAPITokenFile) != "":
+		return tokenFile(os.Getenv(envGodoAPITokenFile))
+	}
+	return nil
+}
+
+// credentialError is a failure to load credentials; it is not a
+// connection problem, so it never sends the client offline
+type credentialError struct {
+	err error
+}
+
+// Error implements error
+func (e *credentialError) Error() string {
+	return "failed to load credentials: " + e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *credentialError) Unwrap() error {
+	return e.err
+}
+
+// authTransport adds the Authorization header to requests and remembers the
+// secrets sent so they can be redacted
+type authTransport struct {
+	base        http.RoundTripper
+	credentials credentials
+
+	mu      sync.Mutex
+	secrets []string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header, secrets, err := t.credentials()
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &credentialError{err: err}
+	}
+
+	t.mu.Lock()
+	t.secrets = secrets
+	t.mu.Unlock()
+
+	// A RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", header)
+	return t.base.RoundTrip(req)
+}
+
+// redact replaces the secrets last sent in s
+func (t *authTransport) redact(s string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, secret := range t.secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
@@ -2,11 +2,15 @@ package notes
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/jonesrussell/dashboard/internal/logger"
@@ -17,7 +21,12 @@ import (
 const (
 	defaultBaseURL    = "http://host.docker.internal:8080"
 	defaultAPITimeout = 10 * time.Second
+	// operationTimeout bounds a whole operation without a caller's context,
+	// including retries and replaying offline changes, since the client's
+	// lock is held throughout
+	operationTimeout  = 30 * time.Second
 	envGodoAPIBaseURL = "GODO_API_URL"
+	envGodoCacheDir   = "GODO_CACHE_DIR"
 )
 
 // Client handles communication with the godo API
@@ -25,6 +34,21 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	logger     logger.Logger
+	retry      RetryPolicy
+	// credentials authenticate requests through auth when set
+	credentials credentials
+	auth        *authTransport
+	// schema selects the request format; with SchemaAuto, detected is set
+	// from the first list response
+	schema   Schema
+	detected Schema
+
+	// cacheDir holds the offline cache and journal; empty disables them
+	cacheDir string
+	// mu guards the journal, which is loaded on first use
+	mu            sync.Mutex
+	journal       []journalEntry
+	journalLoaded bool
 }
 
 // ClientOption allows configuring the client
@@ -51,6 +75,28 @@ func WithLogger(l logger.Logger) ClientOption {
 	}
 }
 
+// WithRetry sets how failed requests are retried
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithSchema selects the task schema instead of detecting it
+func WithSchema(schema Schema) ClientOption {
+	return func(c *Client) {
+		c.schema = schema
+	}
+}
+
+// WithCacheDir sets the directory for the offline cache and journal; an
+// empty dir disables offline support
+func WithCacheDir(dir string) ClientOption {
+	return func(c *Client) {
+		c.cacheDir = dir
+	}
+}
+
 // NewClient creates a new godo API client
 func NewClient(opts ...ClientOption) *Client {
 	// Get base URL from environment or use default
@@ -59,6 +105,11 @@ func NewClient(opts ...ClientOption) *Client {
 		baseURL = defaultBaseURL
 	}
 
+	cacheDir := os.Getenv(envGodoCacheDir)
+	if cacheDir == "" {
+		cacheDir = defaultCacheDir()
+	}
+
 	// Create default logger if none provided
 	defaultLogger, err := logger.New(logger.DefaultConfig())
 	if err != nil {
@@ -78,7 +129,11 @@ func NewClient(opts ...ClientOption) *Client {
 		httpClient: &http.Client{
 			Timeout: defaultAPITimeout,
 		},
-		logger: defaultLogger,
+		logger:      defaultLogger,
+		retry:       DefaultRetryPolicy,
+		credentials: credentialsFromEnv(),
+		schema:      schemaFromEnv(),
+		cacheDir:    cacheDir,
 	}
 
 	// Verify logger is working
@@ -92,6 +147,15 @@ func NewClient(opts ...ClientOption) *Client {
 		opt(client)
 	}
 
+	if client.credentials != nil {
+		base := client.httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		client.auth = &authTransport{base: base, credentials: client.credentials}
+		client.httpClient.Transport = client.auth
+	}
+
 	return client
 }
 
@@ -100,77 +164,56 @@ type TasksResponse struct {
 	Tasks []Note `json:"tasks"`
 }
 
-// Note represents a task from the godo API
+// Note represents a task from the godo API. Content holds the task title in
+// the documented schema.
 type Note struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	Done      bool      `json:"done"`
+	ID          string     `json:"id"`
+	Content     string     `json:"content"`
+	Description string     `json:"description,omitempty"`
+	Done        bool       `json:"done"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Priority is a letter, A being the highest, or empty
+	Priority string     `json:"priority,omitempty"`
+	DueAt    *time.Time `json:"due_at,omitempty"`
+	// Tags are todo.txt +project and @context tags
+	Tags      []string  `json:"tags,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // NoteInput represents the input for creating/updating a task
 type NoteInput struct {
-	Content string `json:"content"`
-	Done    bool   `json:"done,omitempty"`
+	Content     string `json:"content"`
+	Description string `json:"description,omitempty"`
+	Done        bool   `json:"done,omitempty"`
+	// CompletedAt keeps the completion time of a done note; if nil, a note
+	// marked done is completed now
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Priority    string     `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
 }
 
-// ListNotes retrieves all tasks
-func (c *Client) ListNotes() ([]Note, error) {
-	c.logger.Debug("Making notes request",
-		logger.NewField("url", c.baseURL),
-		logger.NewField("method", "GET"),
-		logger.NewField("path", "/api/v1/tasks"),
-	)
-
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/tasks", c.baseURL), nil)
-	if err != nil {
-		c.logger.Error("Failed to create request",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.logger.Error("Failed to make request",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response body for logging
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.logger.Error("Failed to read response body",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	c.logger.Debug("Received response",
-		logger.NewField("body", string(bodyBytes)),
-	)
-
-	// Create a new reader with the body bytes for decoding
-	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-
-	if resp.StatusCode != http.StatusOK {
-		c.logger.Error("Unexpected status code",
-			logger.NewField("status_code", resp.StatusCode),
-		)
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+// listNotes retrieves all tasks from the server
+func (c *Client) listNotes(ctx context.Context) ([]Note, error) {
+	var body json.RawMessage
+	if err := c.do(ctx, http.MethodGet, "/api/v1/tasks", nil, http.StatusOK, &body); err != nil {
+		return nil, err
 	}
 
 	var response TasksResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := json.Unmarshal(body, &response); err != nil {
 		c.logger.Error("Failed to decode response",
 			logger.NewField("error", err),
-			logger.NewField("body", string(bodyBytes)),
 		)
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	if c.schema == SchemaAuto && c.detected == SchemaAuto {
+		if c.detected = detectSchema(body); c.detected != SchemaAuto {
+			c.logger.Debug("Detected task schema",
+				logger.NewField("schema", c.detected.String()),
+			)
+		}
+	}
 
 	c.logger.Debug("Successfully decoded notes",
 		logger.NewField("count", len(response.Tasks)),
@@ -178,193 +221,164 @@ func (c *Client) ListNotes() ([]Note, error) {
 	return response.Tasks, nil
 }
 
-// CreateNote creates a new task
-func (c *Client) CreateNote(input NoteInput) (*Note, error) {
-	c.logger.Debug("Creating note",
-		logger.NewField("content", input.Content),
-	)
-
-	body, err := json.Marshal(input)
-	if err != nil {
-		c.logger.Error("Failed to marshal input",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to marshal input: %w", err)
-	}
-
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/api/v1/tasks", c.baseURL),
-		"application/json",
-		bytes.NewReader(body),
-	)
-	if err != nil {
-		c.logger.Error("Failed to create note",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to create task: %w", err)
+// createNote creates a new task on the server
+func (c *Client) createNote(ctx context.Context, input NoteInput) (*Note, error) {
+	var task Note
+	if err := c.do(ctx, http.MethodPost, "/api/v1/tasks", c.encode(input, time.Now()), http.StatusCreated, &task); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Read and log response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.logger.Error("Failed to read response body",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-	c.logger.Debug("Received response",
-		logger.NewField("status_code", resp.StatusCode),
-		logger.NewField("body", string(bodyBytes)),
+	c.logger.Debug("Successfully created note",
+		logger.NewField("id", task.ID),
 	)
-	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-
-	if resp.StatusCode != http.StatusCreated {
-		c.logger.Error("Unexpected status code",
-			logger.NewField("status_code", resp.StatusCode),
-			logger.NewField("status", resp.Status),
-		)
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
-	}
+	return &task, nil
+}
 
+// updateNote updates an existing note on the server
+func (c *Client) updateNote(ctx context.Context, id string, input NoteInput) (*Note, error) {
 	var task Note
-	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
-		c.logger.Error("Failed to decode response",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.do(ctx, http.MethodPut, "/api/v1/tasks/"+url.PathEscape(id), c.encode(input, time.Now()), http.StatusOK, &task); err != nil {
+		return nil, err
 	}
 
-	c.logger.Debug("Successfully created note",
+	c.logger.Debug("Successfully updated note",
 		logger.NewField("id", task.ID),
 	)
 	return &task, nil
 }
 
-// UpdateNote updates an existing note
-func (c *Client) UpdateNote(id string, input NoteInput) (*Note, error) {
-	c.logger.Debug("Updating note",
-		logger.NewField("id", id),
-		logger.NewField("content", input.Content),
-	)
-
-	body, err := json.Marshal(input)
-	if err != nil {
-		c.logger.Error("Failed to marshal input",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to marshal input: %w", err)
+// deleteNote deletes a note on the server
+func (c *Client) deleteNote(ctx context.Context, id string) error {
+	if err := c.do(ctx, http.MethodDelete, "/api/v1/tasks/"+url.PathEscape(id), nil, http.StatusNoContent, nil); err != nil {
+		return err
 	}
 
-	req, err := http.NewRequest(
-		http.MethodPut,
-		fmt.Sprintf("%s/api/v1/tasks/%s", c.baseURL, id),
-		bytes.NewReader(body),
+	c.logger.Debug("Successfully deleted note",
+		logger.NewField("id", id),
 	)
-	if err != nil {
-		c.logger.Error("Failed to create request",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	return nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.logger.Error("Failed to update note",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to update task: %w", err)
+// do sends a request with input encoded as JSON, expecting the want status,
+// and decodes the response into out if it is non-nil. Failures are retried
+// according to the retry policy.
+func (c *Client) do(ctx context.Context, method, path string, input any, want int, out any) error {
+	var body []byte
+	if input != nil {
+		var err error
+		if body, err = json.Marshal(input); err != nil {
+			c.logger.Error("Failed to marshal input",
+				logger.NewField("error", err),
+			)
+			return fmt.Errorf("failed to marshal input: %w", err)
+		}
 	}
-	defer resp.Body.Close()
 
-	// Read and log response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.logger.Error("Failed to read response body",
+	for attempt := 1; ; attempt++ {
+		respBody, retryAfter, err := c.send(ctx, method, path, body, want)
+		if err == nil {
+			if out == nil {
+				return nil
+			}
+			if err := json.Unmarshal(respBody, out); err != nil {
+				c.logger.Error("Failed to decode response",
+					logger.NewField("error", err),
+				)
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
+		}
+
+		if attempt >= c.retry.MaxAttempts || !retryable(method, err) {
+			return err
+		}
+		delay, ok := c.retry.delay(attempt, retryAfter)
+		if !ok {
+			// The server asked to wait longer than the policy allows
+			return err
+		}
+		c.logger.Debug("Retrying request",
+			logger.NewField("method", method),
+			logger.NewField("path", path),
+			logger.NewField("attempt", attempt),
+			logger.NewField("delay", delay),
 			logger.NewField("error", err),
 		)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-	c.logger.Debug("Received response",
-		logger.NewField("status_code", resp.StatusCode),
-		logger.NewField("body", string(bodyBytes)),
-	)
-	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
-	if resp.StatusCode != http.StatusOK {
-		c.logger.Error("Unexpected status code",
-			logger.NewField("status_code", resp.StatusCode),
-			logger.NewField("status", resp.Status),
-		)
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-timer.C:
+		}
 	}
+}
 
-	var task Note
-	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
-		c.logger.Error("Failed to decode response",
-			logger.NewField("error", err),
-		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// redact removes credentials from text before it is logged
+func (c *Client) redact(s string) string {
+	if c.auth == nil {
+		return s
 	}
-
-	c.logger.Debug("Successfully updated note",
-		logger.NewField("id", task.ID),
-	)
-	return &task, nil
+	return c.auth.redact(s)
 }
 
-// DeleteNote deletes a note
-func (c *Client) DeleteNote(id string) error {
-	c.logger.Debug("Deleting note",
-		logger.NewField("id", id),
+// send makes a single request, returning the response body and any
+// Retry-After delay
+func (c *Client) send(ctx context.Context, method, path string, body []byte, want int) ([]byte, time.Duration, error) {
+	c.logger.Debug("Making request",
+		logger.NewField("url", c.baseURL),
+		logger.NewField("method", method),
+		logger.NewField("path", path),
 	)
 
-	req, err := http.NewRequest(
-		http.MethodDelete,
-		fmt.Sprintf("%s/api/v1/tasks/%s", c.baseURL, id),
-		nil,
-	)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
 	if err != nil {
 		c.logger.Error("Failed to create request",
 			logger.NewField("error", err),
 		)
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		c.logger.Error("Failed to delete note",
+		c.logger.Error("Failed to make request",
 			logger.NewField("error", err),
 		)
-		return fmt.Errorf("failed to delete task: %w", err)
+		var credErr *credentialError
+		if errors.As(err, &credErr) {
+			return nil, 0, credErr
+		}
+		return nil, 0, fmt.Errorf("%w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
-	// Read and log response body
-	bodyBytes, err := io.ReadAll(resp.Body)
+	// Read the response body for logging
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		c.logger.Error("Failed to read response body",
 			logger.NewField("error", err),
 		)
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, 0, fmt.Errorf("failed to read response body: %w", err)
 	}
 	c.logger.Debug("Received response",
 		logger.NewField("status_code", resp.StatusCode),
-		logger.NewField("body", string(bodyBytes)),
+		logger.NewField("body", c.redact(string(respBody))),
 	)
-	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
-	if resp.StatusCode != http.StatusNoContent {
+	if resp.StatusCode != want {
 		c.logger.Error("Unexpected status code",
 			logger.NewField("status_code", resp.StatusCode),
 			logger.NewField("status", resp.Status),
 		)
-		return fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), newStatusError(resp.StatusCode, respBody)
 	}
-
-	c.logger.Debug("Successfully deleted note",
-		logger.NewField("id", id),
-	)
-	return nil
+	return respBody, 0, nil
 }
@@ -0,0 +1,83 @@
+package notes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors reported by the client, matched with errors.Is
+var (
+	// ErrNotFound means the note does not exist on the server
+	ErrNotFound = errors.New("note not found")
+	// ErrUnauthorized means the server rejected the credentials
+	ErrUnauthorized = errors.New("not authorized")
+	// ErrValidation means the server rejected the input; the StatusError
+	// carries its message
+	ErrValidation = errors.New("invalid note")
+	// ErrUnavailable means the server could not be reached or is
+	// temporarily failing
+	ErrUnavailable = errors.New("notes service unavailable")
+)
+
+// StatusError is an unexpected response from the server
+type StatusError struct {
+	StatusCode int
+	// Message is the server's explanation, if it gave one
+	Message string
+}
+
+// newStatusError builds a StatusError from a response, extracting the
+// message from a JSON error body or plain text
+func newStatusError(code int, body []byte) *StatusError {
+	var payload struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &payload) == nil {
+		message = payload.Error
+		if message == "" {
+			message = payload.Message
+		}
+	}
+	return &StatusError{StatusCode: code, Message: message}
+}
+
+// Error implements error
+func (e *StatusError) Error() string {
+	var kind string
+	switch {
+	case errors.Is(e, ErrNotFound):
+		return ErrNotFound.Error()
+	case errors.Is(e, ErrUnauthorized):
+		kind = fmt.Sprintf("%s (%d %s)", ErrUnauthorized, e.StatusCode, http.StatusText(e.StatusCode))
+	case errors.Is(e, ErrValidation):
+		kind = ErrValidation.Error()
+	case errors.Is(e, ErrUnavailable):
+		kind = fmt.Sprintf("%s (%d %s)", ErrUnavailable, e.StatusCode, http.StatusText(e.StatusCode))
+	default:
+		kind = fmt.Sprintf("unexpected status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Message == "" {
+		return kind
+	}
+	return kind + ": " + e.Message
+}
+
+// Is maps status codes to the client's sentinel errors
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
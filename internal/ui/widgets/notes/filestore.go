@@ -0,0 +1,464 @@
+package notes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// envNotesFile selects a local notes file instead of the godo API
+const envNotesFile = "DASHBOARD_NOTES_FILE"
+
+// FileStore keeps notes in a local file: a JSON array of notes, for .md
+// and .markdown files a checklist of "- [ ] item" lines that stays easy to
+// edit by hand, or for .txt files the todo.txt format. The file is reread
+// for every operation so external edits are never overwritten.
+//
+// Markdown and todo.txt files have no IDs, so they are derived from the
+// text: editing a note changes its ID, and a change still using the old ID
+// fails with ErrNotFound.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a store for the notes file at path, which is created
+// on the first change
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// document is a parsed notes file
+type document interface {
+	notes() []Note
+	create(input NoteInput, now time.Time) (Note, error)
+	// update returns false if there is no note with id
+	update(id string, input NoteInput, now time.Time) (Note, bool, error)
+	delete(id string) bool
+	encode() []byte
+}
+
+// ListNotes implements NotesStore
+func (s *FileStore) ListNotes() ([]Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return doc.notes(), nil
+}
+
+// CreateNote implements NotesStore
+func (s *FileStore) CreateNote(input NoteInput) (*Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	note, err := doc.create(input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.save(doc); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// UpdateNote implements NotesStore
+func (s *FileStore) UpdateNote(id string, input NoteInput) (*Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	note, ok, err := doc.update(id, input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := s.save(doc); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteNote implements NotesStore
+func (s *FileStore) DeleteNote(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, err := s.load()
+	if err != nil {
+		return err
+	}
+	if !doc.delete(id) {
+		return ErrNotFound
+	}
+	return s.save(doc)
+}
+
+// Fingerprint summarizes the file's state so external changes are noticed
+func (s *FileStore) Fingerprint() string {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// load reads and parses the file, which may not exist yet
+func (s *FileStore) load() (document, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read notes file: %w", err)
+	}
+
+	switch s.format() {
+	case formatMarkdown:
+		return parseMarkdown(string(data)), nil
+	case formatTodo:
+		return parseTodo(string(data)), nil
+	}
+	doc := &jsonDocument{}
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := json.Unmarshal(data, &doc.list); err != nil {
+			return nil, fmt.Errorf("failed to parse notes file: %w", err)
+		}
+	}
+	return doc, nil
+}
+
+// save writes the document back atomically
+func (s *FileStore) save(doc document) error {
+	if err := writeFile(s.path, doc.encode()); err != nil {
+		return fmt.Errorf("failed to write notes file: %w", err)
+	}
+	return nil
+}
+
+// Notes file formats
+const (
+	formatJSON = iota
+	formatMarkdown
+	formatTodo
+)
+
+// format returns the file format from the extension
+func (s *FileStore) format() int {
+	switch strings.ToLower(filepath.Ext(s.path)) {
+	case ".md", ".markdown":
+		return formatMarkdown
+	case ".txt":
+		return formatTodo
+	}
+	return formatJSON
+}
+
+// jsonDocument is a JSON array of notes
+type jsonDocument struct {
+	list []Note
+}
+
+func (d *jsonDocument) notes() []Note {
+	return append([]Note{}, d.list...)
+}
+
+func (d *jsonDocument) create(input NoteInput, now time.Time) (Note, error) {
+	note := Note{
+		ID:          d.newID(now),
+		Content:     input.Content,
+		Description: input.Description,
+		Done:        input.Done,
+		Priority:    input.Priority,
+		DueAt:       input.DueAt,
+		CompletedAt: completionTime(input, now),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	d.list = append(d.list, note)
+	return note, nil
+}
+
+func (d *jsonDocument) update(id string, input NoteInput, now time.Time) (Note, bool, error) {
+	for i := range d.list {
+		if d.list[i].ID != id {
+			continue
+		}
+		d.list[i].Content = input.Content
+		d.list[i].Description = input.Description
+		d.list[i].Done = input.Done
+		d.list[i].Priority = input.Priority
+		d.list[i].DueAt = input.DueAt
+		d.list[i].CompletedAt = completionTime(input, now)
+		d.list[i].UpdatedAt = now
+		return d.list[i], true, nil
+	}
+	return Note{}, false, nil
+}
+
+func (d *jsonDocument) delete(id string) bool {
+	for i := range d.list {
+		if d.list[i].ID == id {
+			d.list = append(d.list[:i], d.list[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (d *jsonDocument) encode() []byte {
+	data, _ := json.MarshalIndent(d.notes(), "", "  ")
+	return append(data, '\n')
+}
+
+// newID returns an ID not used by another note
+func (d *jsonDocument) newID(now time.Time) string {
+	for n := now.UnixNano(); ; n++ {
+		id := strconv.FormatInt(n, 36)
+		if !slices.ContainsFunc(d.list, func(n Note) bool { return n.ID == id }) {
+			return id
+		}
+	}
+}
+
+// checklistItem matches a Markdown task list item: indentation and bullet,
+// check mark and text
+var checklistItem = regexp.MustCompile(`^(\s*[-*+]) \[([ xX])\](?: (.*))?$`)
+
+// markdownLine is a line of a Markdown notes file; lines that are not
+// checklist items are kept as they are
+type markdownLine struct {
+	text string
+	item bool
+	// bullet is the item's indentation and list marker
+	bullet      string
+	done        bool
+	content     string
+	priority    string
+	due         *time.Time
+	description []string
+}
+
+// markdownPriority matches a trailing priority marker such as " (A)"
+var markdownPriority = regexp.MustCompile(` \(([A-Z])\)$`)
+
+// markdownDocument is a Markdown file with checklist items. Indented lines
+// following an item are its description.
+type markdownDocument struct {
+	lines []markdownLine
+}
+
+// parseMarkdown parses a Markdown notes file
+func parseMarkdown(data string) *markdownDocument {
+	doc := &markdownDocument{}
+	data = strings.TrimSuffix(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	if data == "" {
+		return doc
+	}
+
+	// last is the index of the item indented lines belong to, or -1
+	last := -1
+	for _, text := range strings.Split(data, "\n") {
+		if m := checklistItem.FindStringSubmatch(text); m != nil {
+			line := markdownLine{item: true, bullet: m[1], done: m[2] != " "}
+			line.content, line.priority, line.due = parseMarkers(strings.TrimSpace(m[3]))
+			doc.lines = append(doc.lines, line)
+			last = len(doc.lines) - 1
+			continue
+		}
+		if last >= 0 && strings.TrimSpace(text) != "" && (strings.HasPrefix(text, "  ") || strings.HasPrefix(text, "\t")) {
+			doc.lines[last].description = append(doc.lines[last].description, strings.TrimSpace(text))
+			continue
+		}
+		doc.lines = append(doc.lines, markdownLine{text: text})
+		last = -1
+	}
+	return doc
+}
+
+func (d *markdownDocument) notes() []Note {
+	var notes []Note
+	seen := make(map[string]int)
+	for _, line := range d.lines {
+		if !line.item {
+			continue
+		}
+		notes = append(notes, line.note(markdownID(line.content, seen)))
+	}
+	return notes
+}
+
+func (d *markdownDocument) create(input NoteInput, _ time.Time) (Note, error) {
+	if err := validateMarkdown(input); err != nil {
+		return Note{}, err
+	}
+	d.lines = append(d.lines, markdownItem("-", input))
+	notes := d.notes()
+	return notes[len(notes)-1], nil
+}
+
+func (d *markdownDocument) update(id string, input NoteInput, _ time.Time) (Note, bool, error) {
+	i := d.indexOf(id)
+	if i < 0 {
+		return Note{}, false, nil
+	}
+	if err := validateMarkdown(input); err != nil {
+		return Note{}, true, err
+	}
+	d.lines[i] = markdownItem(d.lines[i].bullet, input)
+
+	// The ID follows the content, so find the updated item by position
+	seen := make(map[string]int)
+	for j, line := range d.lines {
+		if !line.item {
+			continue
+		}
+		id := markdownID(line.content, seen)
+		if j == i {
+			return line.note(id), true, nil
+		}
+	}
+	return Note{}, false, nil
+}
+
+func (d *markdownDocument) delete(id string) bool {
+	i := d.indexOf(id)
+	if i < 0 {
+		return false
+	}
+	d.lines = append(d.lines[:i], d.lines[i+1:]...)
+	return true
+}
+
+func (d *markdownDocument) encode() []byte {
+	var b strings.Builder
+	for _, line := range d.lines {
+		if !line.item {
+			b.WriteString(line.text)
+			b.WriteRune('\n')
+			continue
+		}
+		mark := " "
+		if line.done {
+			mark = "x"
+		}
+		fmt.Fprintf(&b, "%s [%s] %s\n", line.bullet, mark, line.content+markers(line.priority, line.due))
+		indent := strings.Repeat(" ", len(line.bullet)+1)
+		for _, desc := range line.description {
+			b.WriteString(indent + desc + "\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+// indexOf returns the line of the item with id, or -1
+func (d *markdownDocument) indexOf(id string) int {
+	seen := make(map[string]int)
+	for i, line := range d.lines {
+		if line.item && markdownID(line.content, seen) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// markdownItem builds a checklist line from input
+func markdownItem(bullet string, input NoteInput) markdownLine {
+	line := markdownLine{item: true, bullet: bullet, done: input.Done, content: input.Content, priority: input.Priority, due: input.DueAt}
+	for _, desc := range strings.Split(input.Description, "\n") {
+		if desc = strings.TrimSpace(desc); desc != "" {
+			line.description = append(line.description, desc)
+		}
+	}
+	return line
+}
+
+// note converts the item to a Note with the given ID
+func (l markdownLine) note(id string) Note {
+	return Note{
+		ID:          id,
+		Content:     l.content,
+		Description: strings.Join(l.description, "\n"),
+		Done:        l.done,
+		Priority:    l.priority,
+		DueAt:       l.due,
+	}
+}
+
+// parseMarkers splits trailing " (A)" priority and " due:YYYY-MM-DD"
+// markers, in either order, from an item's text
+func parseMarkers(text string) (content, priority string, due *time.Time) {
+	for {
+		if m := markdownPriority.FindStringSubmatch(text); m != nil && priority == "" {
+			priority = m[1]
+			text = strings.TrimSpace(text[:len(text)-len(m[0])])
+			continue
+		}
+		i := strings.LastIndex(text, " due:")
+		if i < 0 || due != nil {
+			return text, priority, due
+		}
+		date, err := time.ParseInLocation(todoDate, text[i+len(" due:"):], time.Local)
+		if err != nil {
+			return text, priority, due
+		}
+		due = &date
+		text = strings.TrimSpace(text[:i])
+	}
+}
+
+// markers formats the trailing markers of an item
+func markers(priority string, due *time.Time) string {
+	var s string
+	if priority != "" {
+		s += " (" + priority + ")"
+	}
+	if due != nil {
+		s += " due:" + due.Format(todoDate)
+	}
+	return s
+}
+
+// markdownID derives a stable ID from an item's content
+func markdownID(content string, seen map[string]int) string {
+	return contentID("md-", content, seen)
+}
+
+// contentID derives an ID from content for files without IDs, numbering
+// repeated content in order of appearance; seen counts the IDs so far
+func contentID(prefix, content string, seen map[string]int) string {
+	h := fnv.New32a()
+	h.Write([]byte(content))
+	id := fmt.Sprintf("%s%08x", prefix, h.Sum32())
+	seen[id]++
+	if n := seen[id]; n > 1 {
+		id += "-" + strconv.Itoa(n)
+	}
+	return id
+}
+
+// validateMarkdown checks input fits on a checklist line
+func validateMarkdown(input NoteInput) error {
+	if strings.ContainsAny(input.Content, "\r\n") {
+		return fmt.Errorf("%w: content must be a single line", ErrValidation)
+	}
+	return nil
+}
@@ -1,6 +1,57 @@
 package notes
 
 // Message types for the notes widget
-type notesMsg []Note
+type notesMsg struct {
+	notes []Note
+	// offline is set when the notes come from the offline cache
+	offline bool
+	// err reports a problem that did not prevent listing, such as queued
+	// changes dropped on replay
+	err error
+}
+
+type refreshMsg struct{}
+
+type watchMsg struct {
+	fingerprint string
+}
 type errorMsg error
 type loadingMsg bool
+
+// opKind identifies a note operation
+type opKind int
+
+const (
+	opCreate opKind = iota
+	opUpdate
+	opDelete
+)
+
+// String returns the verb describing the operation
+func (k opKind) String() string {
+	switch k {
+	case opCreate:
+		return "create"
+	case opDelete:
+		return "delete"
+	}
+	return "update"
+}
+
+// opMsg reports the result of a server call for a note changed locally
+type opMsg struct {
+	kind opKind
+	// id is the note's ID when the operation started
+	id string
+	// prev is the note before the change, restored on failure
+	prev Note
+	// index is where a deleted note was, so it can be reinserted
+	index int
+	// note is the server's copy after a successful create or update
+	note *Note
+	err  error
+}
+
+type toastExpiredMsg struct {
+	id int
+}
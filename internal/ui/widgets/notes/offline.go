@@ -0,0 +1,473 @@
+package notes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonesrussell/dashboard/internal/logger"
+)
+
+// ErrOffline is returned, wrapping the connection error, when the server is
+// unreachable and notes were served from the cache or a change was queued
+var ErrOffline = errors.New("offline")
+
+// ErrConflict is returned for a queued change that was dropped because the
+// note changed on the server while offline
+var ErrConflict = errors.New("changed on server while offline")
+
+const (
+	cacheFile   = "notes.json"
+	journalFile = "journal.json"
+	// offlineIDPrefix marks IDs of notes created while offline
+	offlineIDPrefix = "offline-"
+)
+
+// journalOp is the kind of change recorded in the journal
+type journalOp string
+
+const (
+	journalCreate journalOp = "create"
+	journalUpdate journalOp = "update"
+	journalDelete journalOp = "delete"
+)
+
+// journalEntry is a change made while offline, replayed once the server is
+// reachable again. Each note has at most one entry; later changes are merged.
+type journalEntry struct {
+	Op    journalOp `json:"op"`
+	ID    string    `json:"id"`
+	Input NoteInput `json:"input"`
+	// BaseUpdatedAt is the note's UpdatedAt before it was first changed
+	// offline; a different value on the server is a conflict
+	BaseUpdatedAt time.Time `json:"base_updated_at,omitempty"`
+	QueuedAt      time.Time `json:"queued_at"`
+}
+
+// ListNotes retrieves all tasks, giving up after operationTimeout
+func (c *Client) ListNotes() ([]Note, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+	return c.ListNotesContext(ctx)
+}
+
+// ListNotesContext retrieves all tasks. Changes queued while offline are
+// replayed first, dropping those that conflict with server changes; they are
+// reported with ErrConflict alongside the notes. If the server is
+// unreachable the cached notes, including queued changes, are returned with
+// ErrOffline.
+func (c *Client) ListNotesContext(ctx context.Context) ([]Note, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadJournal()
+
+	notes, err := c.listNotes(ctx)
+	if err != nil {
+		return c.offlineNotes(err)
+	}
+
+	var errs []error
+	if len(c.journal) > 0 {
+		var replayErrs []error
+		replayErrs, err = c.replay(ctx, notes)
+		errs = append(errs, replayErrs...)
+		if err == nil {
+			notes, err = c.listNotes(ctx)
+		}
+		if err != nil {
+			// The server went away during the replay
+			if cerr := c.writeCache(notes); cerr != nil {
+				c.logger.Error("Failed to write notes cache", logger.NewField("error", cerr))
+			}
+			return applyJournal(c.journal, notes), errors.Join(append(errs, fmt.Errorf("%w: %v", ErrOffline, err))...)
+		}
+	}
+
+	if err := c.writeCache(notes); err != nil {
+		c.logger.Error("Failed to write notes cache", logger.NewField("error", err))
+	}
+	return notes, errors.Join(errs...)
+}
+
+// CreateNote creates a new task, giving up after operationTimeout
+func (c *Client) CreateNote(input NoteInput) (*Note, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+	return c.CreateNoteContext(ctx, input)
+}
+
+// CreateNoteContext creates a new task, queueing it under an offline ID if
+// the server is unreachable
+func (c *Client) CreateNoteContext(ctx context.Context, input NoteInput) (*Note, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadJournal()
+
+	cause := errQueued
+	if len(c.journal) == 0 {
+		note, err := c.createNote(ctx, input)
+		if !c.queueable(err) {
+			return note, err
+		}
+		cause = err
+	}
+
+	now := time.Now()
+	entry := journalEntry{
+		Op:       journalCreate,
+		ID:       fmt.Sprintf("%s%d", offlineIDPrefix, now.UnixNano()),
+		Input:    input,
+		QueuedAt: now,
+	}
+	if err := c.queue(entry); err != nil {
+		return nil, err
+	}
+	return &Note{ID: entry.ID, Content: input.Content, Description: input.Description, Done: input.Done, CompletedAt: input.CompletedAt, Priority: input.Priority, DueAt: input.DueAt, CreatedAt: now, UpdatedAt: now}, offlineError(cause)
+}
+
+// UpdateNote updates an existing note, giving up after operationTimeout
+func (c *Client) UpdateNote(id string, input NoteInput) (*Note, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+	return c.UpdateNoteContext(ctx, id, input)
+}
+
+// UpdateNoteContext updates an existing note, queueing the change if the
+// server is unreachable
+func (c *Client) UpdateNoteContext(ctx context.Context, id string, input NoteInput) (*Note, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadJournal()
+
+	cause := errQueued
+	if len(c.journal) == 0 {
+		note, err := c.updateNote(ctx, id, input)
+		if !c.queueable(err) {
+			return note, err
+		}
+		cause = err
+	}
+
+	now := time.Now()
+	entry := journalEntry{
+		Op:       journalUpdate,
+		ID:       id,
+		Input:    input,
+		QueuedAt: now,
+	}
+	base := c.cachedNote(id)
+	if base != nil {
+		entry.BaseUpdatedAt = base.UpdatedAt
+	}
+	if err := c.queue(entry); err != nil {
+		return nil, err
+	}
+
+	note := &Note{ID: id, Content: input.Content, Description: input.Description, Done: input.Done, CompletedAt: input.CompletedAt, Priority: input.Priority, DueAt: input.DueAt, UpdatedAt: now}
+	if base != nil {
+		note.CreatedAt = base.CreatedAt
+	}
+	return note, offlineError(cause)
+}
+
+// DeleteNote deletes a note, giving up after operationTimeout
+func (c *Client) DeleteNote(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+	return c.DeleteNoteContext(ctx, id)
+}
+
+// DeleteNoteContext deletes a note, queueing the deletion if the server is
+// unreachable
+func (c *Client) DeleteNoteContext(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadJournal()
+
+	cause := errQueued
+	if len(c.journal) == 0 {
+		err := c.deleteNote(ctx, id)
+		if !c.queueable(err) {
+			return err
+		}
+		cause = err
+	}
+
+	entry := journalEntry{
+		Op:       journalDelete,
+		ID:       id,
+		QueuedAt: time.Now(),
+	}
+	if base := c.cachedNote(id); base != nil {
+		entry.Input = NoteInput{Content: base.Content, Description: base.Description, Done: base.Done, CompletedAt: base.CompletedAt}
+		entry.BaseUpdatedAt = base.UpdatedAt
+	}
+	if err := c.queue(entry); err != nil {
+		return err
+	}
+	return offlineError(cause)
+}
+
+// errQueued is the cause reported for changes queued behind earlier ones
+// that have not been replayed yet
+var errQueued = errors.New("earlier changes are waiting to be replayed")
+
+// offlineError wraps the reason a change was queued
+func offlineError(cause error) error {
+	return fmt.Errorf("%w: %v", ErrOffline, cause)
+}
+
+// queueable reports whether a failed call should be queued for replay
+func (c *Client) queueable(err error) bool {
+	return err != nil && c.cacheDir != "" && isUnreachable(err)
+}
+
+// isUnreachable reports whether err is a connection failure rather than an
+// error response from the server or a cancelled request
+func isUnreachable(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled)
+}
+
+// offlineNotes returns the cached notes with queued changes applied, or err
+// if there is nothing to show
+func (c *Client) offlineNotes(err error) ([]Note, error) {
+	if !isUnreachable(err) || c.cacheDir == "" {
+		return nil, err
+	}
+	cached, cerr := c.readCache()
+	if cerr != nil {
+		c.logger.Error("Failed to read notes cache", logger.NewField("error", cerr))
+		return nil, err
+	}
+	if cached == nil && len(c.journal) == 0 {
+		return nil, err
+	}
+	return applyJournal(c.journal, cached), offlineError(err)
+}
+
+// replay sends queued changes to the server, whose current notes are given.
+// It returns errors for dropped changes, and the error if the server became
+// unreachable or is temporarily failing, in which case the rest stay queued.
+func (c *Client) replay(ctx context.Context, server []Note) ([]error, error) {
+	current := make(map[string]Note, len(server))
+	for _, note := range server {
+		current[note.ID] = note
+	}
+
+	var errs []error
+	for len(c.journal) > 0 {
+		entry := c.journal[0]
+		var err error
+		note, exists := current[entry.ID]
+		switch {
+		case entry.Op == journalCreate:
+			_, err = c.createNote(ctx, entry.Input)
+		case !exists && entry.Op == journalDelete:
+			// Already deleted on the server
+		case !exists:
+			errs = append(errs, fmt.Errorf("note %q was deleted: %w", entry.Input.Content, ErrConflict))
+		case !note.UpdatedAt.Equal(entry.BaseUpdatedAt):
+			errs = append(errs, fmt.Errorf("note %q: %w", note.Content, ErrConflict))
+		case entry.Op == journalUpdate:
+			_, err = c.updateNote(ctx, entry.ID, entry.Input)
+		default:
+			err = c.deleteNote(ctx, entry.ID)
+		}
+		if isUnreachable(err) || errors.Is(err, ErrUnavailable) {
+			return errs, err
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to replay %s: %w", entry.Op, err))
+		}
+
+		// Persist progress so a crash does not replay a change twice
+		c.logger.Debug("Replayed offline change",
+			logger.NewField("op", string(entry.Op)),
+			logger.NewField("id", entry.ID),
+		)
+		if err := c.setJournal(c.journal[1:]); err != nil {
+			c.logger.Error("Failed to write notes journal", logger.NewField("error", err))
+			c.journal = c.journal[1:]
+		}
+	}
+	return errs, nil
+}
+
+// queue records an offline change, merging it with an earlier change to the
+// same note
+func (c *Client) queue(entry journalEntry) error {
+	journal := append([]journalEntry(nil), c.journal...)
+	merged := false
+	for i := range journal {
+		if journal[i].ID != entry.ID {
+			continue
+		}
+		switch {
+		case journal[i].Op == journalCreate && entry.Op == journalUpdate:
+			journal[i].Input = entry.Input
+		case journal[i].Op == journalCreate && entry.Op == journalDelete:
+			// Never reached the server
+			journal = append(journal[:i], journal[i+1:]...)
+		default:
+			// Conflicts are detected against the state before going offline
+			entry.BaseUpdatedAt = journal[i].BaseUpdatedAt
+			if entry.Op == journalDelete {
+				entry.Input = journal[i].Input
+			}
+			journal[i] = entry
+		}
+		merged = true
+		break
+	}
+	if !merged {
+		journal = append(journal, entry)
+	}
+	return c.setJournal(journal)
+}
+
+// applyJournal returns notes with the queued changes applied
+func applyJournal(journal []journalEntry, notes []Note) []Note {
+	out := append([]Note{}, notes...)
+	for _, entry := range journal {
+		i := -1
+		for j := range out {
+			if out[j].ID == entry.ID {
+				i = j
+				break
+			}
+		}
+		switch {
+		case entry.Op == journalCreate:
+			out = append(out, Note{
+				ID:          entry.ID,
+				Content:     entry.Input.Content,
+				Description: entry.Input.Description,
+				Done:        entry.Input.Done,
+				CompletedAt: entry.Input.CompletedAt,
+				Priority:    entry.Input.Priority,
+				DueAt:       entry.Input.DueAt,
+				CreatedAt:   entry.QueuedAt,
+				UpdatedAt:   entry.QueuedAt,
+			})
+		case i < 0:
+			// Deleted on the server, reported as a conflict on replay
+		case entry.Op == journalUpdate:
+			out[i].Content = entry.Input.Content
+			out[i].Description = entry.Input.Description
+			out[i].Done = entry.Input.Done
+			out[i].CompletedAt = entry.Input.CompletedAt
+			out[i].Priority = entry.Input.Priority
+			out[i].DueAt = entry.Input.DueAt
+			out[i].UpdatedAt = entry.QueuedAt
+		default:
+			out = append(out[:i], out[i+1:]...)
+		}
+	}
+	return out
+}
+
+// cachedNote returns the last known server copy of a note, or nil
+func (c *Client) cachedNote(id string) *Note {
+	cached, err := c.readCache()
+	if err != nil {
+		c.logger.Error("Failed to read notes cache", logger.NewField("error", err))
+	}
+	for i := range cached {
+		if cached[i].ID == id {
+			return &cached[i]
+		}
+	}
+	return nil
+}
+
+// loadJournal reads the journal on first use
+func (c *Client) loadJournal() {
+	if c.journalLoaded || c.cacheDir == "" {
+		return
+	}
+	c.journalLoaded = true
+	if err := readJSON(filepath.Join(c.cacheDir, journalFile), &c.journal); err != nil {
+		c.logger.Error("Failed to read notes journal", logger.NewField("error", err))
+	}
+}
+
+// setJournal persists and replaces the journal
+func (c *Client) setJournal(journal []journalEntry) error {
+	if err := writeJSON(filepath.Join(c.cacheDir, journalFile), journal); err != nil {
+		return fmt.Errorf("failed to write notes journal: %w", err)
+	}
+	c.journal = journal
+	return nil
+}
+
+// readCache returns the notes from the last successful list, or nil if
+// there are none
+func (c *Client) readCache() ([]Note, error) {
+	if c.cacheDir == "" {
+		return nil, nil
+	}
+	var notes []Note
+	if err := readJSON(filepath.Join(c.cacheDir, cacheFile), &notes); err != nil {
+		return nil, fmt.Errorf("failed to read notes cache: %w", err)
+	}
+	return notes, nil
+}
+
+// writeCache stores the notes from a successful list
+func (c *Client) writeCache(notes []Note) error {
+	if c.cacheDir == "" {
+		return nil
+	}
+	if notes == nil {
+		notes = []Note{}
+	}
+	return writeJSON(filepath.Join(c.cacheDir, cacheFile), notes)
+}
+
+// readJSON decodes a file into v, leaving v unchanged if it does not exist
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeJSON encodes v to a file atomically
+func writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// writeFile replaces a file atomically, creating its directory
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// defaultCacheDir returns the notes directory in the user cache directory
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "dashboard", "notes")
+}
@@ -0,0 +1,69 @@
+package notes
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures retries of failed requests. Delays grow
+// exponentially from BaseDelay up to MaxDelay with full jitter, unless the
+// server asks for a specific delay with Retry-After; a request is not
+// retried if that is longer than MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; 1 disables retries
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries briefly so the widget stays responsive
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// delay returns how long to wait after the given failed attempt, or false
+// if the server's Retry-After is too long to wait for
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= p.MaxDelay
+	}
+	ceiling := p.MaxDelay
+	if shift := attempt - 1; shift < 32 && p.BaseDelay<<shift < ceiling {
+		ceiling = p.BaseDelay << shift
+	}
+	if ceiling <= 0 {
+		return 0, true
+	}
+	return rand.N(ceiling + 1), true
+}
+
+// retryable reports whether a failed request may be sent again. Connection
+// failures and server errors are retried only for idempotent methods, since
+// a create may have been applied; 429 means the request was not processed.
+func retryable(method string, err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return method != http.MethodPost && errors.Is(err, ErrUnavailable)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date, returning 0 if it is absent or invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,30 @@
+package notes
+
+import "time"
+
+// NotesStore persists notes. Client stores them on a godo server and
+// FileStore in a local file.
+type NotesStore interface {
+	ListNotes() ([]Note, error)
+	CreateNote(input NoteInput) (*Note, error)
+	UpdateNote(id string, input NoteInput) (*Note, error)
+	DeleteNote(id string) error
+}
+
+// fingerprinter is implemented by stores that can change outside the
+// dashboard; a changed fingerprint means the notes should be reloaded
+type fingerprinter interface {
+	Fingerprint() string
+}
+
+// completionTime returns when a note saved from input was completed: its
+// existing completion time, now if newly done, or nil if not done
+func completionTime(input NoteInput, now time.Time) *time.Time {
+	if !input.Done {
+		return nil
+	}
+	if completed(input.CompletedAt) {
+		return input.CompletedAt
+	}
+	return &now
+}
@@ -0,0 +1,294 @@
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// todoDate is the date format of todo.txt
+const todoDate = "2006-01-02"
+
+// todoPriority matches a leading todo.txt priority such as "(A) "
+var todoPriority = regexp.MustCompile(`^\(([A-Z])\) `)
+
+// todoTask is a task line of a todo.txt file
+type todoTask struct {
+	done      bool
+	priority  string
+	completed time.Time
+	created   time.Time
+	// text is the description including +project, @context and key:value tags
+	text string
+}
+
+// todoLine is a line of a todo.txt file; blank lines are kept as they are
+type todoLine struct {
+	raw  string
+	task *todoTask
+}
+
+// todoDocument is a todo.txt file (see https://github.com/todotxt/todo.txt)
+type todoDocument struct {
+	lines []todoLine
+}
+
+// parseTodo parses a todo.txt file
+func parseTodo(data string) *todoDocument {
+	doc := &todoDocument{}
+	data = strings.TrimSuffix(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	if data == "" {
+		return doc
+	}
+	for _, text := range strings.Split(data, "\n") {
+		if strings.TrimSpace(text) == "" {
+			doc.lines = append(doc.lines, todoLine{raw: text})
+			continue
+		}
+		task := parseTodoTask(text)
+		doc.lines = append(doc.lines, todoLine{task: &task})
+	}
+	return doc
+}
+
+// parseTodoTask parses a task line: "x" and a completion date for done
+// tasks, or a priority, then an optional creation date and the text.
+// Completed tasks keep their priority as a pri:X tag.
+func parseTodoTask(line string) todoTask {
+	var task todoTask
+	rest := line
+	if strings.HasPrefix(rest, "x ") {
+		task.done = true
+		rest = rest[2:]
+		task.completed, rest = todoLeadingDate(rest)
+	} else if m := todoPriority.FindStringSubmatch(rest); m != nil {
+		task.priority = m[1]
+		rest = rest[len(m[0]):]
+	}
+	task.created, rest = todoLeadingDate(rest)
+
+	if task.done {
+		words := strings.Fields(rest)
+		for i, word := range words {
+			if p, ok := strings.CutPrefix(word, "pri:"); ok && len(p) == 1 && p[0] >= 'A' && p[0] <= 'Z' {
+				task.priority = p
+				words = append(words[:i], words[i+1:]...)
+				rest = strings.Join(words, " ")
+				break
+			}
+		}
+	}
+	task.text = strings.TrimSpace(rest)
+	return task
+}
+
+// todoLeadingDate parses a date at the start of s, returning the rest
+func todoLeadingDate(s string) (time.Time, string) {
+	if len(s) < len(todoDate) {
+		return time.Time{}, s
+	}
+	date, err := time.ParseInLocation(todoDate, s[:len(todoDate)], time.Local)
+	if err != nil || (len(s) > len(todoDate) && s[len(todoDate)] != ' ') {
+		return time.Time{}, s
+	}
+	return date, strings.TrimPrefix(s[len(todoDate):], " ")
+}
+
+// String formats the task as a todo.txt line
+func (t todoTask) String() string {
+	var parts []string
+	if t.done {
+		parts = append(parts, "x")
+		if !t.completed.IsZero() {
+			parts = append(parts, t.completed.Format(todoDate))
+		}
+	} else if t.priority != "" {
+		parts = append(parts, "("+t.priority+")")
+	}
+	// The creation date follows the completion date if there is one
+	if !t.created.IsZero() && (!t.done || !t.completed.IsZero()) {
+		parts = append(parts, t.created.Format(todoDate))
+	}
+	parts = append(parts, t.text)
+	if t.done && t.priority != "" {
+		parts = append(parts, "pri:"+t.priority)
+	}
+	return strings.Join(parts, " ")
+}
+
+// tags returns the task's +project and @context tags
+func (t todoTask) tags() []string {
+	var tags []string
+	for _, word := range strings.Fields(t.text) {
+		if len(word) > 1 && (word[0] == '+' || word[0] == '@') {
+			tags = append(tags, word)
+		}
+	}
+	return tags
+}
+
+// due returns the date of the task's due:YYYY-MM-DD tag, or nil
+func (t todoTask) due() *time.Time {
+	for _, word := range strings.Fields(t.text) {
+		if value, ok := strings.CutPrefix(word, "due:"); ok {
+			if date, err := time.ParseInLocation(todoDate, value, time.Local); err == nil {
+				return &date
+			}
+		}
+	}
+	return nil
+}
+
+// withDue sets the due: tag of text to due, keeping its position, or
+// removes it if due is nil
+func withDue(text string, due *time.Time) string {
+	tag := ""
+	if due != nil {
+		tag = "due:" + due.Format(todoDate)
+	}
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if !strings.HasPrefix(word, "due:") {
+			words = append(words, word)
+		} else if tag != "" {
+			words = append(words, tag)
+			tag = ""
+		}
+	}
+	if tag != "" {
+		words = append(words, tag)
+	}
+	return strings.Join(words, " ")
+}
+
+// note converts the task to a Note with the given ID
+func (t todoTask) note(id string) Note {
+	note := Note{
+		ID:        id,
+		Content:   t.text,
+		Done:      t.done,
+		Priority:  t.priority,
+		Tags:      t.tags(),
+		DueAt:     t.due(),
+		CreatedAt: t.created,
+	}
+	if t.done && !t.completed.IsZero() {
+		completed := t.completed
+		note.CompletedAt = &completed
+	}
+	return note
+}
+
+// apply sets the task from input. A leading "(A) " in the content sets the
+// priority, and the due date replaces any due: tag in the content unless
+// that tag was edited.
+func (t *todoTask) apply(input NoteInput, now time.Time) error {
+	if strings.ContainsAny(input.Content, "\r\n") {
+		return fmt.Errorf("%w: content must be a single line", ErrValidation)
+	}
+
+	text := strings.TrimSpace(input.Content)
+	t.priority = input.Priority
+	if m := todoPriority.FindStringSubmatch(text + " "); m != nil {
+		t.priority = m[1]
+		text = strings.TrimSpace(text[len(m[0])-1:])
+	}
+	due := input.DueAt
+	// A due: tag edited in the content wins over the note's due date
+	if edited, prev := (todoTask{text: text}).due(), t.due(); (edited == nil) != (prev == nil) || (edited != nil && !edited.Equal(*prev)) {
+		due = edited
+	}
+	t.text = withDue(text, due)
+	t.done = input.Done
+	t.completed = time.Time{}
+	if completedAt := completionTime(input, now); completedAt != nil {
+		t.completed = *completedAt
+	}
+	return nil
+}
+
+func (d *todoDocument) notes() []Note {
+	var notes []Note
+	seen := make(map[string]int)
+	for _, line := range d.lines {
+		if line.task != nil {
+			notes = append(notes, line.task.note(todoID(line.task.text, seen)))
+		}
+	}
+	return notes
+}
+
+func (d *todoDocument) create(input NoteInput, now time.Time) (Note, error) {
+	task := todoTask{created: now}
+	if err := task.apply(input, now); err != nil {
+		return Note{}, err
+	}
+	d.lines = append(d.lines, todoLine{task: &task})
+	notes := d.notes()
+	return notes[len(notes)-1], nil
+}
+
+func (d *todoDocument) update(id string, input NoteInput, now time.Time) (Note, bool, error) {
+	i := d.indexOf(id)
+	if i < 0 {
+		return Note{}, false, nil
+	}
+	task := *d.lines[i].task
+	if err := task.apply(input, now); err != nil {
+		return Note{}, true, err
+	}
+	d.lines[i].task = &task
+
+	// The ID follows the text, so find the updated task by position
+	seen := make(map[string]int)
+	for j, line := range d.lines {
+		if line.task == nil {
+			continue
+		}
+		id := todoID(line.task.text, seen)
+		if j == i {
+			return task.note(id), true, nil
+		}
+	}
+	return Note{}, false, nil
+}
+
+func (d *todoDocument) delete(id string) bool {
+	i := d.indexOf(id)
+	if i < 0 {
+		return false
+	}
+	d.lines = append(d.lines[:i], d.lines[i+1:]...)
+	return true
+}
+
+func (d *todoDocument) encode() []byte {
+	var b strings.Builder
+	for _, line := range d.lines {
+		if line.task == nil {
+			b.WriteString(line.raw)
+		} else {
+			b.WriteString(line.task.String())
+		}
+		b.WriteRune('\n')
+	}
+	return []byte(b.String())
+}
+
+// indexOf returns the line of the task with id, or -1
+func (d *todoDocument) indexOf(id string) int {
+	seen := make(map[string]int)
+	for i, line := range d.lines {
+		if line.task != nil && todoID(line.task.text, seen) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// todoID derives a stable ID from a task's text without its due date, so
+// completing, reprioritizing or rescheduling a task keeps its ID
+func todoID(text string, seen map[string]int) string {
+	return contentID("todo-", withDue(text, nil), seen)
+}
@@ -1,9 +1,17 @@
 package notes
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jonesrussell/dashboard/internal/logger"
@@ -11,35 +19,174 @@ import (
 	"github.com/jonesrussell/dashboard/internal/ui/styles"
 )
 
+// sortMode selects the order notes are shown in
+type sortMode int
+
+const (
+	sortCreated sortMode = iota
+	sortDue
+	sortPriority
+	sortDoneLast
+	sortModes
+)
+
+// String returns the name shown in the title
+func (s sortMode) String() string {
+	switch s {
+	case sortDue:
+		return "due"
+	case sortPriority:
+		return "priority"
+	case sortDoneLast:
+		return "done last"
+	}
+	return "created"
+}
+
+// compare orders notes for the mode; notes without a due date or priority
+// come last
+func (s sortMode) compare(a, b Note) int {
+	switch s {
+	case sortDue:
+		if a.DueAt == nil || b.DueAt == nil {
+			return compareMissing(a.DueAt == nil, b.DueAt == nil)
+		}
+		return a.DueAt.Compare(*b.DueAt)
+	case sortPriority:
+		if a.Priority == "" || b.Priority == "" {
+			return compareMissing(a.Priority == "", b.Priority == "")
+		}
+		return cmp.Compare(a.Priority, b.Priority)
+	case sortDoneLast:
+		return compareMissing(a.Done, b.Done)
+	}
+	return a.CreatedAt.Compare(b.CreatedAt)
+}
+
+// compareMissing orders a value that is missing after one that is not
+func compareMissing(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
 // Widget represents the notes widget
 type Widget struct {
 	components.BaseWidget
-	client    *Client
+	store     NotesStore
 	notes     []Note
 	selected  int
 	loading   bool
 	lastError error
+
+	// tagFilter shows only notes with this tag when set, filter those
+	// matching its query and hideDone those not done; selected indexes the
+	// notes left visible in sortBy order, and selectedID keeps the selected
+	// note across changes to them
+	tagFilter  string
+	filter     textinput.Model
+	filtering  bool
+	hideDone   bool
+	sortBy     sortMode
+	selectedID string
+
+	// editor is the content input, open when editing is set
+	editor    textinput.Model
+	editing   bool
+	editID    string
+	editError error
+
+	// pending counts in-flight server calls per note ID; renamed maps the old
+	// ID of a note whose store derives IDs from content to its new ID while
+	// calls sent under the old one are in flight
+	pending map[string]int
+	renamed map[string]string
+	localID int
+	toast   string
+	toastID int
+
+	// offline is set while changes are served from and queued to the
+	// client's offline cache; retrying while a reconnect attempt is scheduled
+	offline  bool
+	retrying bool
+
+	// fingerprint is the store's last seen state when it can change
+	// outside the dashboard
+	fingerprint string
 }
 
-// New creates a new notes widget
+const (
+	// maxContentLength is the longest note content accepted by the editor
+	maxContentLength = 500
+	// toastDuration is how long a failed operation's message is shown
+	toastDuration = 5 * time.Second
+	// retryInterval is how often the server is retried while offline
+	retryInterval = 30 * time.Second
+	// watchInterval is how often a notes file is checked for changes
+	watchInterval = 2 * time.Second
+	// localIDPrefix marks IDs of notes not yet created on the server
+	localIDPrefix = "local-"
+	// detailTimeFormat formats dates in the detail pane
+	detailTimeFormat = "Mon 02 Jan 2006 15:04"
+	// dueFormat formats due dates in the list
+	dueFormat = "Mon 02 Jan"
+	// chromeWidth is the border and padding around the widget content
+	chromeWidth = 4
+)
+
+// New creates a new notes widget. Notes are kept in DASHBOARD_NOTES_FILE if
+// set, otherwise on the godo server configured by opts.
 func New(log logger.Logger, opts ...ClientOption) *Widget {
 	if log == nil {
 		panic("logger cannot be nil")
 	}
 
+	if path := os.Getenv(envNotesFile); path != "" {
+		return NewWithStore(log, NewFileStore(path))
+	}
+
 	// Add logger to client options
 	opts = append(opts, WithLogger(log))
+	return NewWithStore(log, NewClient(opts...))
+}
+
+// NewWithStore creates a new notes widget backed by store
+func NewWithStore(log logger.Logger, store NotesStore) *Widget {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	editor := textinput.New()
+	editor.Placeholder = "note"
+	editor.CharLimit = maxContentLength
+
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter notes"
 
 	return &Widget{
-		client:   NewClient(opts...),
+		store:    store,
 		notes:    make([]Note, 0),
 		selected: 0,
+		editor:   editor,
+		filter:   filter,
+		pending:  make(map[string]int),
+		renamed:  make(map[string]string),
 	}
 }
 
 // Init implements components.Widget
 func (w *Widget) Init() tea.Cmd {
-	return w.fetchNotes
+	return tea.Batch(w.fetchNotes, w.watchTick())
+}
+
+// CapturingInput implements components.InputCapturer
+func (w *Widget) CapturingInput() bool {
+	return w.editing || w.filtering
 }
 
 // Update implements components.Widget
@@ -49,39 +196,180 @@ func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
 		if !w.IsFocused() {
 			return w, nil
 		}
+		if w.editing {
+			return w, w.handleEditKey(msg)
+		}
+		if w.filtering {
+			return w, w.handleFilterKey(msg)
+		}
 		switch msg.String() {
 		case "up", "k":
-			if w.selected > 0 {
-				w.selected--
-			}
+			w.moveSelection(-1)
 		case "down", "j":
-			if w.selected < len(w.notes)-1 {
-				w.selected++
+			w.moveSelection(1)
+		case "t":
+			w.cycleTagFilter()
+		case "s":
+			w.sortBy = (w.sortBy + 1) % sortModes
+			w.refreshSelection()
+		case "h":
+			w.hideDone = !w.hideDone
+			w.refreshSelection()
+		case "/":
+			w.filtering = true
+			return w, w.filter.Focus()
+		case "esc":
+			w.filter.SetValue("")
+			w.refreshSelection()
+		case " ", "d", "e":
+			note, ok := w.current()
+			if !ok {
+				return w, nil
 			}
-		case " ":
-			if w.selected >= 0 && w.selected < len(w.notes) {
-				return w, w.toggleNote(w.notes[w.selected].ID)
+			if w.saving(note.ID) {
+				return w, w.showToast("Note is still being saved")
 			}
-		case "d":
-			if w.selected >= 0 && w.selected < len(w.notes) {
-				return w, w.deleteNote(w.notes[w.selected].ID)
+			switch msg.String() {
+			case " ":
+				return w, w.toggleNote(note.ID)
+			case "d":
+				return w, w.deleteNote(note.ID)
+			default:
+				return w, w.startEditing(note.ID, note.Content)
 			}
 		case "n":
-			return w, w.createNote
+			return w, w.startEditing("", "")
 		}
 	case notesMsg:
-		w.notes = msg
+		w.notes = msg.notes
 		w.loading = false
 		w.lastError = nil
+		w.offline = msg.offline
+		if !slices.Contains(w.tags(), w.tagFilter) {
+			w.tagFilter = ""
+		}
+		w.refreshSelection()
+		var cmds []tea.Cmd
+		if msg.err != nil && !msg.offline {
+			cmds = append(cmds, w.showToast(msg.err.Error()))
+		}
+		if msg.offline {
+			cmds = append(cmds, w.retry())
+		}
+		return w, tea.Batch(cmds...)
+	case refreshMsg:
+		w.retrying = false
+		return w, w.fetchNotes
+	case watchMsg:
+		// The first check reloads too, catching changes made since Init
+		changed := msg.fingerprint != w.fingerprint
+		w.fingerprint = msg.fingerprint
+		if changed {
+			return w, tea.Batch(w.fetchNotes, w.watchTick())
+		}
+		return w, w.watchTick()
 	case errorMsg:
 		w.lastError = msg
 		w.loading = false
+		w.offline = false
 	case loadingMsg:
 		w.loading = bool(msg)
+	case opMsg:
+		return w, w.finishOp(msg)
+	case toastExpiredMsg:
+		if msg.id == w.toastID {
+			w.toast = ""
+		}
 	}
 	return w, nil
 }
 
+// handleEditKey processes a key press while the editor is open
+func (w *Widget) handleEditKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		w.stopEditing()
+		return nil
+	case "enter":
+		content, err := validateContent(w.editor.Value())
+		if err != nil {
+			w.editError = err
+			return nil
+		}
+		id := w.editID
+		w.stopEditing()
+		if id == "" {
+			return w.createNote(content)
+		}
+		return w.updateNote(id, content)
+	}
+
+	var cmd tea.Cmd
+	w.editor, cmd = w.editor.Update(msg)
+	w.editError = nil
+	return cmd
+}
+
+// handleFilterKey processes a key press while the filter is open, updating
+// the visible notes as the query changes
+func (w *Widget) handleFilterKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		w.filter.SetValue("")
+		w.stopFiltering()
+	case "enter":
+		w.stopFiltering()
+	default:
+		var cmd tea.Cmd
+		w.filter, cmd = w.filter.Update(msg)
+		w.refreshSelection()
+		return cmd
+	}
+	w.refreshSelection()
+	return nil
+}
+
+// stopFiltering leaves filter input mode, keeping the query
+func (w *Widget) stopFiltering() {
+	w.filtering = false
+	w.filter.Blur()
+}
+
+// startEditing opens the editor for the note with id, or a new note if id is empty
+func (w *Widget) startEditing(id, content string) tea.Cmd {
+	w.editing = true
+	w.editID = id
+	w.editError = nil
+	w.editor.Prompt = "New: "
+	if id != "" {
+		w.editor.Prompt = "Edit: "
+	}
+	w.editor.SetValue(content)
+	w.editor.CursorEnd()
+	return w.editor.Focus()
+}
+
+// stopEditing closes the editor, discarding its content
+func (w *Widget) stopEditing() {
+	w.editing = false
+	w.editID = ""
+	w.editError = nil
+	w.editor.SetValue("")
+	w.editor.Blur()
+}
+
+// validateContent trims note content and checks it can be saved
+func validateContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", errors.New("note cannot be empty")
+	}
+	if len([]rune(content)) > maxContentLength {
+		return "", fmt.Errorf("note is longer than %d characters", maxContentLength)
+	}
+	return content, nil
+}
+
 // View implements components.Widget
 func (w *Widget) View() string {
 	width, height := w.GetDimensions()
@@ -89,8 +377,28 @@ func (w *Widget) View() string {
 	b.Grow(width * height)
 
 	// Title
-	b.WriteString(styles.Title.Render("Notes"))
+	visible := w.visible()
+	if len(w.notes) == 0 {
+		b.WriteString(styles.Title.Render("Notes"))
+	} else {
+		b.WriteString(styles.Title.Render(fmt.Sprintf("Notes (%d of %d, %d done)", len(visible), len(w.notes), w.doneCount())))
+	}
+	if w.tagFilter != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Render(" " + w.tagFilter))
+	}
+	if w.sortBy != sortCreated {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render(" by " + w.sortBy.String()))
+	}
+	if w.hideDone {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render(" hiding done"))
+	}
+	if w.offline {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Warning).Render(" (offline)"))
+	}
 	b.WriteRune('\n')
+	if w.filtering || w.filter.Value() != "" {
+		b.WriteString(w.filter.View())
+	}
 	b.WriteRune('\n')
 
 	// Loading state
@@ -100,45 +408,71 @@ func (w *Widget) View() string {
 		return w.GetStyle().Width(width).Height(height).Render(b.String())
 	}
 
-	// Error state
-	if w.lastError != nil {
+	switch {
+	case w.lastError != nil:
+		// Error state, keeping the editor and help usable
 		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
-		b.WriteString(errorStyle.Render(w.lastError.Error()))
-		return w.GetStyle().Width(width).Height(height).Render(b.String())
-	}
-
-	// Notes
-	if len(w.notes) == 0 {
+		b.WriteString(errorStyle.Render(errorText(w.lastError)))
+		b.WriteRune('\n')
+	case len(w.notes) == 0:
 		subtleStyle := lipgloss.NewStyle().Foreground(styles.Subtle)
 		b.WriteString(subtleStyle.Render("No notes"))
 		b.WriteString("\n\n")
 		b.WriteString(subtleStyle.Render("Press 'n' to create a new note"))
-	} else {
-		for i, note := range w.notes {
-			// Note style
-			noteStyle := lipgloss.NewStyle()
-			if i == w.selected && w.IsFocused() {
-				noteStyle = styles.Selected
-			}
-
-			// Note status
-			status := "[ ]"
-			if note.Done {
-				status = "[✓]"
+		b.WriteRune('\n')
+	case len(visible) == 0:
+		subtleStyle := lipgloss.NewStyle().Foreground(styles.Subtle)
+		b.WriteString(subtleStyle.Render("No matching notes"))
+		b.WriteRune('\n')
+	default:
+		now := time.Now()
+		for i, note := range visible {
+			b.WriteString(renderNote(note, i == w.selected && w.IsFocused(), now))
+			if w.pending[note.ID] > 0 {
+				b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render(" ⋯"))
 			}
+			b.WriteRune('\n')
+		}
 
-			// Format note line
-			noteLine := fmt.Sprintf("%s %s", status, note.Content)
-			b.WriteString(noteStyle.Render(noteLine))
+		// Detail pane for the selected note
+		if detail := w.renderDetail(width - chromeWidth); detail != "" {
 			b.WriteRune('\n')
+			b.WriteString(detail)
+		}
+	}
+
+	// Toast
+	if w.toast != "" {
+		b.WriteString("\n")
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.toast))
+		b.WriteRune('\n')
+	}
+
+	// Editor
+	if w.editing {
+		b.WriteString("\n")
+		b.WriteString(w.editor.View())
+		if w.editError != nil {
+			b.WriteString("\n")
+			errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+			b.WriteString(errorStyle.Render(w.editError.Error()))
 		}
+		b.WriteRune('\n')
 	}
 
 	// Help text
 	if w.IsFocused() {
 		b.WriteString("\n")
 		helpStyle := lipgloss.NewStyle().Foreground(styles.Subtle)
-		b.WriteString(helpStyle.Render("↑/↓: select • space: toggle • n: new • d: delete"))
+		switch {
+		case w.editing:
+			b.WriteString(helpStyle.Render("enter: save • esc: cancel"))
+		case w.filtering:
+			b.WriteString(helpStyle.Render("enter: keep filter • esc: clear"))
+		default:
+			b.WriteString(helpStyle.Render("↑/↓: select • space: toggle • n: new • e: edit • d: delete • /: filter • h: hide done • t: tag • s: sort"))
+		}
 	}
 
 	return w.GetStyle().Width(width).Height(height).Render(b.String())
@@ -147,46 +481,519 @@ func (w *Widget) View() string {
 // Commands
 func (w *Widget) fetchNotes() tea.Msg {
 	w.loading = true
-	notes, err := w.client.ListNotes()
-	if err != nil {
+	notes, err := w.store.ListNotes()
+	switch {
+	case errors.Is(err, ErrOffline):
+		return notesMsg{notes: notes, offline: true}
+	case err != nil && notes == nil:
 		return errorMsg(err)
 	}
-	return notesMsg(notes)
+	return notesMsg{notes: notes, err: err}
+}
+
+// watchTick schedules a check for external changes to stores that support it
+func (w *Widget) watchTick() tea.Cmd {
+	f, ok := w.store.(fingerprinter)
+	if !ok {
+		return nil
+	}
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		return watchMsg{fingerprint: f.Fingerprint()}
+	})
+}
+
+// retry schedules a reconnect attempt unless one is pending
+func (w *Widget) retry() tea.Cmd {
+	if w.retrying {
+		return nil
+	}
+	w.retrying = true
+	return tea.Tick(retryInterval, func(time.Time) tea.Msg {
+		return refreshMsg{}
+	})
 }
 
+// The operations below change the local notes immediately and return a
+// command performing the server call; opMsg carries what is needed to roll
+// the change back if the call fails.
+
 func (w *Widget) toggleNote(id string) tea.Cmd {
+	i := w.indexOf(id)
+	if i < 0 {
+		return nil
+	}
+	prev := w.notes[i]
+	input := NoteInput{
+		Content:     prev.Content,
+		Description: prev.Description,
+		Done:        !prev.Done,
+		Priority:    prev.Priority,
+		DueAt:       prev.DueAt,
+	}
+	if input.Done {
+		now := time.Now()
+		input.CompletedAt = &now
+	}
+	w.notes[i].Done = input.Done
+	w.notes[i].CompletedAt = input.CompletedAt
+	// Done notes may move when sorted last
+	w.refreshSelection()
+	return w.update(prev, input)
+}
+
+func (w *Widget) updateNote(id, content string) tea.Cmd {
+	i := w.indexOf(id)
+	if i < 0 {
+		return nil
+	}
+	prev := w.notes[i]
+	w.notes[i].Content = content
+	input := NoteInput{
+		Content:     content,
+		Description: prev.Description,
+		Done:        prev.Done,
+		CompletedAt: prev.CompletedAt,
+		Priority:    prev.Priority,
+		DueAt:       prev.DueAt,
+	}
+	return w.update(prev, input)
+}
+
+// update sends input for a note already changed locally from prev
+func (w *Widget) update(prev Note, input NoteInput) tea.Cmd {
+	w.pending[prev.ID]++
+	store := w.store
+	return func() tea.Msg {
+		note, err := store.UpdateNote(prev.ID, input)
+		return opMsg{kind: opUpdate, id: prev.ID, prev: prev, note: note, err: err}
+	}
+}
+
+func (w *Widget) deleteNote(id string) tea.Cmd {
+	i := w.indexOf(id)
+	if i < 0 {
+		return nil
+	}
+	prev := w.notes[i]
+	w.notes = append(w.notes[:i], w.notes[i+1:]...)
+	w.refreshSelection()
+	return w.delete(prev, i)
+}
+
+// delete removes a note already removed locally from index
+func (w *Widget) delete(prev Note, index int) tea.Cmd {
+	w.pending[prev.ID]++
+	store := w.store
+	return func() tea.Msg {
+		err := store.DeleteNote(prev.ID)
+		return opMsg{kind: opDelete, id: prev.ID, prev: prev, index: index, err: err}
+	}
+}
+
+func (w *Widget) createNote(content string) tea.Cmd {
+	// The note is shown under a local ID until the server assigns one
+	w.localID++
+	id := fmt.Sprintf("%s%d", localIDPrefix, w.localID)
+	w.notes = append(w.notes, Note{ID: id, Content: content, CreatedAt: time.Now()})
+	w.selectID(id)
+	w.pending[id]++
+	store := w.store
 	return func() tea.Msg {
-		note := w.notes[w.selected]
 		input := NoteInput{
-			Content: note.Content,
-			Done:    !note.Done,
+			Content: content,
+			Done:    false,
 		}
+		note, err := store.CreateNote(input)
+		return opMsg{kind: opCreate, id: id, note: note, err: err}
+	}
+}
 
-		_, err := w.client.UpdateNote(id, input)
-		if err != nil {
-			return errorMsg(err)
+// finishOp applies the result of a server call, rolling the local change
+// back on failure
+func (w *Widget) finishOp(msg opMsg) tea.Cmd {
+	sentID := msg.id
+	if id, ok := w.renamed[msg.id]; ok {
+		msg.id, msg.prev.ID = id, id
+	}
+	if w.pending[msg.id]--; w.pending[msg.id] <= 0 {
+		delete(w.pending, msg.id)
+		for old, id := range w.renamed {
+			if id == msg.id {
+				delete(w.renamed, old)
+			}
+		}
+	}
+
+	if errors.Is(msg.err, ErrOffline) {
+		// Queued by the client; keep the change and retry until it is replayed
+		w.offline = true
+		if i := w.indexOf(msg.id); i >= 0 && msg.kind == opCreate && msg.note != nil {
+			w.replaceLocal(i, *msg.note)
+		}
+		return w.retry()
+	}
+
+	if errors.Is(msg.err, ErrNotFound) && msg.kind != opCreate && sentID != msg.id {
+		// Sent under the ID the note had before an earlier edit renamed it
+		return w.resend(msg)
+	}
+
+	if errors.Is(msg.err, ErrNotFound) && msg.kind != opCreate {
+		// Deleted elsewhere; a local delete has nothing left to do
+		if i := w.indexOf(msg.id); i >= 0 {
+			w.notes = append(w.notes[:i], w.notes[i+1:]...)
+			w.refreshSelection()
+		}
+		if msg.kind == opDelete {
+			return nil
+		}
+		return w.showToast("Note was deleted elsewhere")
+	}
+
+	if msg.err != nil {
+		switch msg.kind {
+		case opCreate:
+			if i := w.indexOf(msg.id); i >= 0 {
+				w.notes = append(w.notes[:i], w.notes[i+1:]...)
+			}
+		case opUpdate:
+			if i := w.indexOf(msg.id); i >= 0 {
+				w.notes[i] = msg.prev
+			}
+		case opDelete:
+			i := min(msg.index, len(w.notes))
+			w.notes = append(w.notes[:i], append([]Note{msg.prev}, w.notes[i:]...)...)
+		}
+		w.refreshSelection()
+		return w.showToast(fmt.Sprintf("Failed to %s note: %s", msg.kind, errorText(msg.err)))
+	}
+
+	if msg.note == nil {
+		return nil
+	}
+	i := w.indexOf(msg.id)
+	if i < 0 {
+		return nil
+	}
+	switch msg.kind {
+	case opCreate:
+		w.replaceLocal(i, *msg.note)
+		if n := w.pending[msg.id]; n > 0 {
+			delete(w.pending, msg.id)
+			w.pending[msg.note.ID] = n
+		}
+	case opUpdate:
+		if msg.note.ID != msg.id {
+			w.rename(i, msg.note.ID)
+		}
+		// A newer change to the same note is still in flight
+		if w.pending[msg.note.ID] == 0 {
+			w.notes[i] = *msg.note
 		}
-		return w.fetchNotes()
 	}
+	w.refreshSelection()
+	return nil
 }
 
-func (w *Widget) deleteNote(id string) tea.Cmd {
-	return func() tea.Msg {
-		if err := w.client.DeleteNote(id); err != nil {
-			return errorMsg(err)
+// rename moves the note at i, whose ID the store derived from content that
+// has since changed, to its new ID, along with its selection and the calls
+// still in flight for it
+func (w *Widget) rename(i int, id string) {
+	old := w.notes[i].ID
+	w.notes[i].ID = id
+	if w.selectedID == old {
+		w.selectedID = id
+	}
+	if w.editID == old {
+		w.editID = id
+	}
+	if n := w.pending[old]; n > 0 {
+		delete(w.pending, old)
+		w.pending[id] = n
+		w.renamed[old] = id
+	}
+	for prev, to := range w.renamed {
+		if to == old {
+			w.renamed[prev] = id
 		}
-		return w.fetchNotes()
 	}
 }
 
-func (w *Widget) createNote() tea.Msg {
-	input := NoteInput{
-		Content: "New Note",
-		Done:    false,
+// resend repeats a change that reached the store under a note's old ID
+func (w *Widget) resend(msg opMsg) tea.Cmd {
+	if msg.kind == opDelete {
+		return w.delete(msg.prev, msg.index)
 	}
-	_, err := w.client.CreateNote(input)
-	if err != nil {
-		return errorMsg(err)
+	i := w.indexOf(msg.id)
+	if i < 0 {
+		return nil
+	}
+	// The local note already holds the change
+	note := w.notes[i]
+	return w.update(msg.prev, NoteInput{
+		Content:     note.Content,
+		Description: note.Description,
+		Done:        note.Done,
+		CompletedAt: note.CompletedAt,
+		Priority:    note.Priority,
+		DueAt:       note.DueAt,
+	})
+}
+
+// replaceLocal replaces the note at i, created under a local ID, with the
+// saved note, keeping it selected
+func (w *Widget) replaceLocal(i int, note Note) {
+	if w.selectedID == w.notes[i].ID {
+		w.selectedID = note.ID
+	}
+	w.notes[i] = note
+}
+
+// showToast displays a transient error message
+func (w *Widget) showToast(text string) tea.Cmd {
+	w.toast = text
+	w.toastID++
+	id := w.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// renderDetail renders the selected note's description and dates, or
+// nothing if it has no description or completion time
+func (w *Widget) renderDetail(width int) string {
+	note, ok := w.current()
+	if !ok || note.Description == "" && note.CompletedAt == nil {
+		return ""
 	}
-	return w.fetchNotes()
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	width = max(width, 10)
+
+	var b strings.Builder
+	b.WriteString(subtle.Render(strings.Repeat("─", width)))
+	b.WriteRune('\n')
+	if note.Description != "" {
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(note.Description))
+		b.WriteRune('\n')
+	}
+	if note.CompletedAt != nil {
+		b.WriteString(subtle.Render("Completed " + note.CompletedAt.Local().Format(detailTimeFormat)))
+		b.WriteRune('\n')
+	}
+	if !note.CreatedAt.IsZero() {
+		b.WriteString(subtle.Render("Created " + note.CreatedAt.Local().Format(detailTimeFormat)))
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+// priorityColors are the colors of priorities; lower ones are subtle
+var priorityColors = map[string]lipgloss.Color{
+	"A": styles.Critical,
+	"B": styles.Warning,
+	"C": styles.Secondary,
+}
+
+// renderNote renders a note line with its priority, tags and due date
+// highlighted, or plainly in the selection style when selected
+func renderNote(note Note, selected bool, now time.Time) string {
+	status := "[ ]"
+	if note.Done {
+		status = "[✓]"
+	}
+	priority := ""
+	if note.Priority != "" {
+		priority = "(" + note.Priority + ") "
+	}
+
+	due := ""
+	if note.DueAt != nil {
+		due = " due " + note.DueAt.Local().Format(dueFormat)
+	}
+
+	if selected {
+		return styles.Selected.Render(fmt.Sprintf("%s %s%s%s", status, priority, note.Content, due))
+	}
+
+	var b strings.Builder
+	b.WriteString(status + " ")
+	if priority != "" {
+		color, ok := priorityColors[note.Priority]
+		if !ok {
+			color = styles.Subtle
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(color).Render(priority))
+	}
+	tagStyle := lipgloss.NewStyle().Foreground(styles.Primary)
+	for i, word := range strings.Split(note.Content, " ") {
+		if i > 0 {
+			b.WriteRune(' ')
+		}
+		if slices.Contains(note.Tags, word) {
+			word = tagStyle.Render(word)
+		}
+		b.WriteString(word)
+	}
+	if due != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(dueColor(note, now)).Render(due))
+	}
+	return b.String()
+}
+
+// dueColor highlights overdue notes and notes due today that are not done
+func dueColor(note Note, now time.Time) lipgloss.Color {
+	if note.Done {
+		return styles.Subtle
+	}
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	switch due := note.DueAt.In(now.Location()); {
+	case due.Before(today):
+		return styles.Critical
+	case due.Before(today.AddDate(0, 0, 1)):
+		return styles.Warning
+	}
+	return styles.Subtle
+}
+
+// errorText describes a client error for display
+func errorText(err error) string {
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		// Carries the status and the server's message
+		return statusErr.Error()
+	case errors.Is(err, ErrUnavailable):
+		return "notes service unavailable, check " + envGodoAPIBaseURL
+	}
+	return err.Error()
+}
+
+// indexOf returns the position of the note with id, or -1
+func (w *Widget) indexOf(id string) int {
+	for i := range w.notes {
+		if w.notes[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// visible returns the notes shown, in display order
+func (w *Widget) visible() []Note {
+	query := w.filter.Value()
+	var notes []Note
+	for _, note := range w.notes {
+		switch {
+		case w.tagFilter != "" && !slices.Contains(note.Tags, w.tagFilter):
+		case w.hideDone && note.Done:
+		case !fuzzyMatch(query, note.Content):
+		default:
+			notes = append(notes, note)
+		}
+	}
+	slices.SortStableFunc(notes, w.sortBy.compare)
+	return notes
+}
+
+// current returns the selected note
+func (w *Widget) current() (Note, bool) {
+	visible := w.visible()
+	if w.selected < 0 || w.selected >= len(visible) {
+		return Note{}, false
+	}
+	return visible[w.selected], true
+}
+
+// selectID selects the note with id if it is visible
+func (w *Widget) selectID(id string) {
+	w.selectedID = id
+	w.refreshSelection()
+}
+
+// moveSelection moves the cursor by delta notes within bounds
+func (w *Widget) moveSelection(delta int) {
+	visible := w.visible()
+	next := w.selected + delta
+	if next < 0 || next >= len(visible) {
+		return
+	}
+	w.selected = next
+	w.selectedID = visible[next].ID
+}
+
+// refreshSelection follows the selected note as the visible notes change,
+// keeping the selection within them if it is gone
+func (w *Widget) refreshSelection() {
+	visible := w.visible()
+	if i := slices.IndexFunc(visible, func(n Note) bool { return n.ID == w.selectedID }); i >= 0 {
+		w.selected = i
+		return
+	}
+	w.selected = max(min(w.selected, len(visible)-1), 0)
+	if note, ok := w.current(); ok {
+		w.selectedID = note.ID
+	}
+}
+
+// doneCount returns the number of done notes, shown or not
+func (w *Widget) doneCount() int {
+	n := 0
+	for _, note := range w.notes {
+		if note.Done {
+			n++
+		}
+	}
+	return n
+}
+
+// fuzzyMatch reports whether the characters of query appear in text in
+// order, ignoring case and spaces in the query
+func fuzzyMatch(query, text string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(query) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+utf8.RuneLen(r):]
+	}
+	return true
+}
+
+// tags returns the sorted tags of all notes
+func (w *Widget) tags() []string {
+	var tags []string
+	for _, note := range w.notes {
+		for _, tag := range note.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// cycleTagFilter filters by the next tag, then shows all notes again after
+// the last one
+func (w *Widget) cycleTagFilter() {
+	note, _ := w.current()
+	tags := w.tags()
+	// No filter is not found, so it moves to the first tag
+	next := slices.Index(tags, w.tagFilter) + 1
+	w.tagFilter = ""
+	if next < len(tags) {
+		w.tagFilter = tags[next]
+	}
+	w.selectID(note.ID)
+}
+
+// saving reports whether a note has not been created on the server yet
+func (w *Widget) saving(id string) bool {
+	return strings.HasPrefix(id, localIDPrefix) && w.pending[id] > 0
 }
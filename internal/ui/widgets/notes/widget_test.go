@@ -1,15 +1,27 @@
 package notes
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jonesrussell/dashboard/internal/testutil/testlogger"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNotesWidget(t *testing.T) {
+	t.Setenv(envGodoCacheDir, t.TempDir())
 	log, _ := testlogger.NewTestLogger(t, "notes-test")
 	w := New(log)
 
@@ -103,16 +115,18 @@ func TestNotesWidget(t *testing.T) {
 				getCmd func() tea.Msg
 			}{
 				{"toggle note", func() tea.Msg { return w.toggleNote("1")() }},
+				{"update note", func() tea.Msg { return w.updateNote("1", "Edited")() }},
 				{"delete note", func() tea.Msg { return w.deleteNote("1")() }},
-				{"create note", func() tea.Msg { return w.createNote() }},
+				{"create note", func() tea.Msg { return w.createNote("Test Note")() }},
 			}
 
 			for _, tt := range tests {
 				t.Run(tt.name, func(t *testing.T) {
 					msg := tt.getCmd()
 					assert.NotNil(t, msg)
-					_, ok := msg.(errorMsg)
-					assert.True(t, ok, "expected error message type")
+					op, ok := msg.(opMsg)
+					assert.True(t, ok, "expected operation message type")
+					assert.Error(t, op.err)
 				})
 			}
 		})
@@ -133,6 +147,7 @@ func TestNotesWidget(t *testing.T) {
 			wantCmd bool
 		}{
 			{"space to toggle", " ", true},
+			{"e to edit", "e", true},
 			{"d to delete", "d", true},
 			{"n to create", "n", true},
 			{"invalid key", "x", false},
@@ -140,6 +155,7 @@ func TestNotesWidget(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
+				w.stopEditing()
 				_, cmd := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
 				if tt.wantCmd {
 					assert.NotNil(t, cmd)
@@ -149,4 +165,978 @@ func TestNotesWidget(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("editor", func(t *testing.T) {
+		w := New(log)
+		w.notes = []Note{{ID: "1", Content: "Test Note", Done: true}}
+		w.Focus()
+
+		press := func(key tea.KeyMsg) tea.Cmd {
+			_, cmd := w.Update(key)
+			return cmd
+		}
+		typeText := func(text string) {
+			press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+		}
+
+		t.Run("new note", func(t *testing.T) {
+			assert.NotNil(t, press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}))
+			assert.True(t, w.CapturingInput())
+			assert.Empty(t, w.editor.Value())
+
+			typeText("j")
+			assert.Equal(t, 0, w.selected, "keys go to the editor")
+			assert.Equal(t, "j", w.editor.Value())
+
+			press(tea.KeyMsg{Type: tea.KeyEsc})
+			assert.False(t, w.CapturingInput())
+			assert.Empty(t, w.editor.Value())
+		})
+
+		t.Run("empty content is rejected", func(t *testing.T) {
+			press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+			typeText("   ")
+			assert.Nil(t, press(tea.KeyMsg{Type: tea.KeyEnter}))
+			assert.True(t, w.CapturingInput(), "editor stays open")
+			assert.Contains(t, w.View(), "note cannot be empty")
+
+			typeText("x")
+			assert.NotContains(t, w.View(), "note cannot be empty", "typing clears the error")
+			press(tea.KeyMsg{Type: tea.KeyEsc})
+		})
+
+		t.Run("edit selected", func(t *testing.T) {
+			press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+			assert.Equal(t, "Test Note", w.editor.Value())
+			assert.Equal(t, "1", w.editID)
+			assert.Contains(t, w.View(), "Edit: ")
+
+			typeText("!")
+			cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+			assert.NotNil(t, cmd)
+			assert.False(t, w.CapturingInput())
+		})
+	})
+
+	t.Run("sorting", func(t *testing.T) {
+		w := New(log)
+		w.Focus()
+		day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+		due := func(days int) *time.Time {
+			t := day.AddDate(0, 0, days)
+			return &t
+		}
+		w.notes = []Note{
+			{ID: "1", Content: "Oldest", Done: true, CreatedAt: day, DueAt: due(5)},
+			{ID: "2", Content: "Middle", Priority: "B", CreatedAt: day.Add(time.Hour)},
+			{ID: "3", Content: "Newest", Priority: "A", CreatedAt: day.Add(2 * time.Hour), DueAt: due(2)},
+		}
+		ids := func() []string {
+			var ids []string
+			for _, note := range w.visible() {
+				ids = append(ids, note.ID)
+			}
+			return ids
+		}
+		press := func(key string) {
+			w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+
+		assert.Equal(t, []string{"1", "2", "3"}, ids())
+		press("j")
+		press("s")
+		assert.Equal(t, sortDue, w.sortBy)
+		assert.Equal(t, []string{"3", "1", "2"}, ids(), "no due date last")
+		assert.Equal(t, 2, w.selected, "selection follows the note")
+		press("s")
+		assert.Equal(t, []string{"3", "2", "1"}, ids())
+		press("s")
+		assert.Equal(t, []string{"2", "3", "1"}, ids())
+		assert.Contains(t, w.View(), "by done last")
+
+		press(" ")
+		assert.Equal(t, []string{"3", "1", "2"}, ids(), "toggled note moves")
+		note, ok := w.current()
+		require.True(t, ok)
+		assert.Equal(t, "2", note.ID, "and stays selected")
+		press("s")
+		assert.Equal(t, sortCreated, w.sortBy)
+
+		now := day.Add(12 * time.Hour)
+		assert.Equal(t, styles.Critical, dueColor(Note{DueAt: due(-1)}, now), "overdue")
+		assert.Equal(t, styles.Warning, dueColor(Note{DueAt: &day}, now), "due today")
+		assert.Equal(t, styles.Subtle, dueColor(Note{DueAt: due(1)}, now))
+		assert.Equal(t, styles.Subtle, dueColor(Note{DueAt: due(-1), Done: true}, now))
+	})
+
+	t.Run("search and hide done", func(t *testing.T) {
+		w := New(log)
+		w.Focus()
+		w.notes = []Note{
+			{ID: "1", Content: "Buy milk", Done: true},
+			{ID: "2", Content: "Book flights"},
+			{ID: "3", Content: "Call Sam"},
+			{ID: "4", Content: "Bread rolls"},
+		}
+		typeKeys := func(keys string) {
+			for _, r := range keys {
+				w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+		}
+		assert.Contains(t, w.View(), "Notes (4 of 4, 1 done)")
+
+		typeKeys("/bk")
+		assert.True(t, w.CapturingInput(), "filter takes the keys")
+		assert.True(t, fuzzyMatch("bk", "Book flights"))
+		assert.Contains(t, w.View(), "Notes (2 of 4, 1 done)", "matches in order, ignoring case")
+		assert.NotContains(t, w.View(), "Call Sam")
+		assert.NotContains(t, w.View(), "Bread rolls")
+
+		w.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.False(t, w.CapturingInput())
+		typeKeys("j")
+		note, ok := w.current()
+		require.True(t, ok)
+		assert.Equal(t, "2", note.ID)
+		typeKeys("h")
+		assert.Contains(t, w.View(), "Notes (1 of 4, 1 done)")
+		note, _ = w.current()
+		assert.Equal(t, "2", note.ID, "selection follows the note")
+
+		cmd := w.deleteNote(note.ID)
+		assert.NotNil(t, cmd)
+		assert.Equal(t, []string{"1", "3", "4"}, []string{w.notes[0].ID, w.notes[1].ID, w.notes[2].ID},
+			"actions use the underlying IDs")
+		assert.Contains(t, w.View(), "No matching notes")
+
+		w.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.Contains(t, w.View(), "Notes (2 of 3, 1 done)", "esc clears the query")
+		typeKeys("h")
+		assert.Contains(t, w.View(), "Notes (3 of 3, 1 done)")
+	})
+
+	t.Run("validate content", func(t *testing.T) {
+		content, err := validateContent("  Buy milk ")
+		assert.NoError(t, err)
+		assert.Equal(t, "Buy milk", content)
+
+		_, err = validateContent(strings.Repeat("x", maxContentLength+1))
+		assert.Error(t, err)
+	})
+
+	t.Run("optimistic updates", func(t *testing.T) {
+		var fail bool
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if fail {
+				rw.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			switch r.Method {
+			case http.MethodPost:
+				rw.WriteHeader(http.StatusCreated)
+				fmt.Fprint(rw, `{"id":"42","content":"Created"}`)
+			case http.MethodPut:
+				var input NoteInput
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+				json.NewEncoder(rw).Encode(Note{ID: "1", Content: input.Content, Done: input.Done})
+			case http.MethodDelete:
+				rw.WriteHeader(http.StatusNoContent)
+			}
+		}))
+		defer srv.Close()
+
+		w := New(log, WithBaseURL(srv.URL), WithCacheDir(t.TempDir()))
+		w.notes = []Note{{ID: "1", Content: "First"}, {ID: "2", Content: "Second"}}
+		w.Focus()
+
+		t.Run("toggle applies before the server responds", func(t *testing.T) {
+			cmd := w.toggleNote("1")
+			assert.True(t, w.notes[0].Done)
+			assert.Contains(t, w.View(), "⋯", "pending marker")
+
+			w.Update(cmd())
+			assert.True(t, w.notes[0].Done)
+			assert.Empty(t, w.pending)
+			assert.NotContains(t, w.View(), "⋯")
+		})
+
+		t.Run("create replaces the local ID", func(t *testing.T) {
+			cmd := w.createNote("Created")
+			require.Len(t, w.notes, 3)
+			assert.True(t, w.saving(w.notes[2].ID))
+
+			_, toast := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" ")})
+			assert.NotNil(t, toast, "unsaved notes cannot be changed")
+			assert.Contains(t, w.View(), "Note is still being saved")
+
+			w.Update(cmd())
+			assert.Equal(t, "42", w.notes[2].ID)
+			assert.Empty(t, w.pending)
+		})
+
+		t.Run("failures roll back", func(t *testing.T) {
+			fail = true
+			defer func() { fail = false }()
+
+			cmd := w.deleteNote("2")
+			assert.Len(t, w.notes, 2)
+			_, toast := w.Update(cmd())
+			assert.NotNil(t, toast)
+			require.Len(t, w.notes, 3)
+			assert.Equal(t, "2", w.notes[1].ID, "reinserted in place")
+			assert.Contains(t, w.View(), "Failed to delete note")
+
+			cmd = w.updateNote("1", "Edited")
+			w.Update(cmd())
+			assert.Equal(t, "First", w.notes[0].Content)
+			assert.True(t, w.notes[0].Done)
+
+			cmd = w.createNote("Lost")
+			w.Update(cmd())
+			assert.Len(t, w.notes, 3)
+			assert.Empty(t, w.pending)
+			assert.Nil(t, w.lastError, "the list stays visible")
+		})
+
+		t.Run("toast expires", func(t *testing.T) {
+			w.Update(toastExpiredMsg{id: w.toastID - 1})
+			assert.NotEmpty(t, w.toast, "stale expiry is ignored")
+			w.Update(toastExpiredMsg{id: w.toastID})
+			assert.Empty(t, w.toast)
+		})
+	})
+}
+
+// fakeServer is an in-memory godo API that can simulate being unreachable
+type fakeServer struct {
+	mu     sync.Mutex
+	notes  []Note
+	nextID int
+	down   bool
+	// failWrites answers changes with this status when set
+	failWrites int
+}
+
+func (f *fakeServer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		conn, _, _ := rw.(http.Hijacker).Hijack()
+		conn.Close()
+		return
+	}
+	if f.failWrites != 0 && r.Method != http.MethodGet {
+		rw.WriteHeader(f.failWrites)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/tasks/")
+	now := time.Now()
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(rw).Encode(TasksResponse{Tasks: f.notes})
+	case http.MethodPost:
+		var input NoteInput
+		json.NewDecoder(r.Body).Decode(&input)
+		f.nextID++
+		note := Note{ID: fmt.Sprint(f.nextID), Content: input.Content, Done: input.Done, CreatedAt: now, UpdatedAt: now}
+		f.notes = append(f.notes, note)
+		rw.WriteHeader(http.StatusCreated)
+		json.NewEncoder(rw).Encode(note)
+	case http.MethodPut:
+		var input NoteInput
+		json.NewDecoder(r.Body).Decode(&input)
+		for i := range f.notes {
+			if f.notes[i].ID == id {
+				f.notes[i].Content, f.notes[i].Done, f.notes[i].UpdatedAt = input.Content, input.Done, now
+				json.NewEncoder(rw).Encode(f.notes[i])
+				return
+			}
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	case http.MethodDelete:
+		for i := range f.notes {
+			if f.notes[i].ID == id {
+				f.notes = append(f.notes[:i], f.notes[i+1:]...)
+				rw.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeServer) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func TestOfflineClient(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "notes-offline-test")
+	fake := &fakeServer{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	cacheDir := t.TempDir()
+	newClient := func() *Client {
+		return NewClient(WithBaseURL(srv.URL), WithCacheDir(cacheDir), WithLogger(log))
+	}
+	c := newClient()
+
+	_, err := c.CreateNote(NoteInput{Content: "Keep"})
+	require.NoError(t, err)
+	_, err = c.CreateNote(NoteInput{Content: "Remove"})
+	require.NoError(t, err)
+	notes, err := c.ListNotes()
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+
+	t.Run("cached notes while offline", func(t *testing.T) {
+		fake.setDown(true)
+		notes, err := c.ListNotes()
+		assert.ErrorIs(t, err, ErrOffline)
+		assert.Len(t, notes, 2)
+	})
+
+	t.Run("changes are journaled", func(t *testing.T) {
+		created, err := c.CreateNote(NoteInput{Content: "Offline"})
+		assert.ErrorIs(t, err, ErrOffline)
+		assert.True(t, strings.HasPrefix(created.ID, offlineIDPrefix))
+
+		_, err = c.UpdateNote(created.ID, NoteInput{Content: "Offline, edited"})
+		assert.ErrorIs(t, err, ErrOffline)
+		_, err = c.UpdateNote("1", NoteInput{Content: "Keep", Done: true})
+		assert.ErrorIs(t, err, ErrOffline)
+		assert.ErrorIs(t, c.DeleteNote("2"), ErrOffline)
+		assert.Len(t, c.journal, 3, "the offline edit is merged into its create")
+
+		// A fresh client sees the durable journal
+		notes, err := newClient().ListNotes()
+		assert.ErrorIs(t, err, ErrOffline)
+		require.Len(t, notes, 2)
+		assert.True(t, notes[0].Done)
+		assert.Equal(t, "Offline, edited", notes[1].Content)
+	})
+
+	t.Run("replay on reconnect detects conflicts", func(t *testing.T) {
+		// Someone else edits the note queued for deletion
+		fake.mu.Lock()
+		fake.notes[1].Content = "Remove, edited elsewhere"
+		fake.notes[1].UpdatedAt = fake.notes[1].UpdatedAt.Add(time.Second)
+		fake.mu.Unlock()
+		fake.setDown(false)
+
+		notes, err := c.ListNotes()
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.NotErrorIs(t, err, ErrOffline)
+		require.Len(t, notes, 3)
+		assert.True(t, notes[0].Done)
+		assert.Equal(t, "Remove, edited elsewhere", notes[1].Content, "server wins the conflict")
+		assert.Equal(t, "Offline, edited", notes[2].Content)
+		assert.Empty(t, c.journal)
+
+		notes, err = newClient().ListNotes()
+		assert.NoError(t, err, "nothing left to replay")
+		assert.Len(t, notes, 3)
+	})
+
+	t.Run("replay keeps changes while the server is failing", func(t *testing.T) {
+		fake.setDown(true)
+		_, err := c.CreateNote(NoteInput{Content: "Queued"})
+		require.ErrorIs(t, err, ErrOffline)
+
+		fake.mu.Lock()
+		fake.failWrites = http.StatusServiceUnavailable
+		fake.mu.Unlock()
+		fake.setDown(false)
+
+		notes, err := c.ListNotes()
+		assert.ErrorIs(t, err, ErrOffline)
+		assert.Contains(t, err.Error(), "503")
+		require.Len(t, c.journal, 1, "the change stays queued")
+		assert.Equal(t, "Queued", notes[len(notes)-1].Content)
+		fresh := newClient()
+		fresh.loadJournal()
+		assert.Len(t, fresh.journal, 1, "the durable journal still holds it")
+
+		fake.mu.Lock()
+		fake.failWrites = 0
+		fake.mu.Unlock()
+		notes, err = c.ListNotes()
+		require.NoError(t, err)
+		assert.Len(t, notes, 4)
+		assert.Empty(t, c.journal)
+	})
+
+	t.Run("widget shows offline state", func(t *testing.T) {
+		fake.setDown(true)
+		defer fake.setDown(false)
+
+		w := New(log, WithBaseURL(srv.URL), WithCacheDir(cacheDir))
+		_, cmd := w.Update(w.fetchNotes())
+		assert.NotNil(t, cmd, "reconnect is scheduled")
+		assert.Contains(t, w.View(), "(offline)")
+		assert.Contains(t, w.View(), "Offline, edited")
+
+		w.selected = 0
+		_, cmd = w.Update(w.toggleNote(w.notes[0].ID)())
+		assert.Nil(t, cmd, "a reconnect is already scheduled")
+		assert.False(t, w.notes[0].Done, "queued change is kept")
+		assert.Empty(t, w.toast)
+	})
+}
+
+func TestClientErrors(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "notes-errors-test")
+	fast := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	var hits int
+	var respond func(rw http.ResponseWriter, r *http.Request)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		hits++
+		respond(rw, r)
+	}))
+	defer srv.Close()
+	c := NewClient(WithBaseURL(srv.URL), WithCacheDir(""), WithRetry(fast), WithLogger(log))
+
+	t.Run("retries server errors honouring Retry-After", func(t *testing.T) {
+		hits = 0
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			if hits < 3 {
+				rw.Header().Set("Retry-After", "0")
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(rw, `{"tasks":[{"id":"1"}]}`)
+		}
+		notes, err := c.ListNotesContext(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, notes, 1)
+		assert.Equal(t, 3, hits)
+	})
+
+	t.Run("creates are not retried on server errors", func(t *testing.T) {
+		hits = 0
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+		_, err := c.CreateNote(NoteInput{Content: "x"})
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.Equal(t, 1, hits)
+	})
+
+	t.Run("too many requests is retried", func(t *testing.T) {
+		hits = 0
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusTooManyRequests)
+		}
+		_, err := c.CreateNote(NoteInput{Content: "x"})
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.Equal(t, fast.MaxAttempts, hits)
+	})
+
+	t.Run("typed errors", func(t *testing.T) {
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusNotFound)
+		}
+		assert.ErrorIs(t, c.DeleteNote("1"), ErrNotFound)
+
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(rw, `{"error":"title is required"}`)
+		}
+		_, err := c.UpdateNote("1", NoteInput{})
+		assert.ErrorIs(t, err, ErrValidation)
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, "title is required", statusErr.Message)
+		assert.Equal(t, "invalid note: title is required", errorText(err))
+	})
+
+	t.Run("long Retry-After is not waited for", func(t *testing.T) {
+		hits = 0
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.Header().Set("Retry-After", "3600")
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+		start := time.Now()
+		_, err := c.ListNotesContext(context.Background())
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.Equal(t, 1, hits)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("cancellation stops retrying", func(t *testing.T) {
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.Header().Set("Retry-After", "60")
+			rw.WriteHeader(http.StatusBadGateway)
+		}
+		patient := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Minute}
+		c := NewClient(WithBaseURL(srv.URL), WithCacheDir(""), WithRetry(patient), WithLogger(log))
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := c.ListNotesContext(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		c := NewClient(WithBaseURL("http://127.0.0.1:1"), WithCacheDir(""), WithRetry(fast), WithLogger(log))
+		_, err := c.ListNotes()
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.Equal(t, "notes service unavailable, check GODO_API_URL", errorText(err))
+	})
+
+	t.Run("widget drops notes deleted elsewhere", func(t *testing.T) {
+		respond = func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusNotFound)
+		}
+		w := New(log, WithBaseURL(srv.URL), WithCacheDir(""), WithRetry(fast))
+		w.notes = []Note{{ID: "1", Content: "Gone"}, {ID: "2", Content: "Also gone"}}
+
+		_, cmd := w.Update(w.toggleNote("1")())
+		assert.NotNil(t, cmd)
+		assert.Equal(t, "Note was deleted elsewhere", w.toast)
+		_, cmd = w.Update(w.deleteNote("2")())
+		assert.Nil(t, cmd, "deleting a missing note succeeds")
+		assert.Empty(t, w.notes)
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt := 1; attempt <= 40; attempt++ {
+		d, ok := p.delay(attempt, 0)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, min(p.BaseDelay<<min(attempt-1, 10), p.MaxDelay))
+	}
+	d, ok := p.delay(1, 200*time.Millisecond)
+	assert.True(t, ok)
+	assert.Equal(t, 200*time.Millisecond, d, "Retry-After wins")
+	_, ok = p.delay(1, 7*time.Second)
+	assert.False(t, ok, "Retry-After over MaxDelay is not waited for")
+
+	now := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter("-5", now))
+}
+
+func TestClientAuth(t *testing.T) {
+	log, logPath := testlogger.NewTestLogger(t, "notes-auth-test")
+	fast := RetryPolicy{MaxAttempts: 1}
+
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if gotAuth == "" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// Echo the credentials back so redaction can be checked
+		fmt.Fprintf(rw, `{"tasks":[],"auth":%q}`, gotAuth)
+	}))
+	defer srv.Close()
+
+	newClient := func(opts ...ClientOption) *Client {
+		opts = append([]ClientOption{WithBaseURL(srv.URL), WithCacheDir(""), WithRetry(fast), WithLogger(log)}, opts...)
+		return NewClient(opts...)
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := newClient().ListNotes()
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Equal(t, "not authorized (401 Unauthorized)", errorText(err))
+	})
+
+	t.Run("static token", func(t *testing.T) {
+		_, err := newClient(WithToken("static-secret")).ListNotes()
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer static-secret", gotAuth)
+	})
+
+	t.Run("token file is reread", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(path, []byte("file-secret-1\n"), 0o600))
+		c := newClient(WithTokenFile(path))
+
+		_, err := c.ListNotes()
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer file-secret-1", gotAuth)
+
+		require.NoError(t, os.WriteFile(path, []byte("file-secret-2"), 0o600))
+		_, err = c.ListNotes()
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer file-secret-2", gotAuth)
+	})
+
+	t.Run("missing token file is not offline", func(t *testing.T) {
+		c := newClient(WithTokenFile(filepath.Join(t.TempDir(), "missing")), WithCacheDir(t.TempDir()))
+		_, err := c.ListNotes()
+		assert.ErrorContains(t, err, "failed to load credentials")
+		assert.NotErrorIs(t, err, ErrOffline)
+		assert.NotErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("token from env", func(t *testing.T) {
+		t.Setenv(envGodoAPIToken, "env-secret")
+		_, err := newClient().ListNotes()
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer env-secret", gotAuth)
+
+		t.Setenv("CUSTOM_TOKEN", "custom-secret")
+		_, err = newClient(WithTokenEnv("CUSTOM_TOKEN")).ListNotes()
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer custom-secret", gotAuth)
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		_, err := newClient(WithBasicAuth("me", "basic-secret")).ListNotes()
+		require.NoError(t, err)
+		req := &http.Request{Header: http.Header{"Authorization": {gotAuth}}}
+		user, pass, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "me", user)
+		assert.Equal(t, "basic-secret", pass)
+	})
+
+	t.Run("credentials are redacted from logs", func(t *testing.T) {
+		data, err := os.ReadFile(logPath)
+		require.NoError(t, err)
+		logged := string(data)
+		assert.Contains(t, logged, redacted)
+		for _, secret := range []string{"static-secret", "file-secret-1", "file-secret-2", "env-secret", "custom-secret", "basic-secret"} {
+			assert.NotContains(t, logged, secret)
+		}
+	})
+}
+
+func TestSchema(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "notes-schema-test")
+	completedAt := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	t.Run("decodes both schemas", func(t *testing.T) {
+		var notes []Note
+		require.NoError(t, json.Unmarshal([]byte(`[
+			{"id":"1","title":"Task","description":"Details","completed_at":"2024-03-05T09:00:00Z"},
+			{"id":"2","title":"Reopened","completed_at":"0001-01-01T00:00:00Z"},
+			{"id":"3","content":"Legacy","done":true}
+		]`), &notes))
+
+		assert.Equal(t, "Task", notes[0].Content)
+		assert.Equal(t, "Details", notes[0].Description)
+		assert.True(t, notes[0].Done)
+		assert.Equal(t, completedAt, *notes[0].CompletedAt)
+
+		assert.False(t, notes[1].Done, "zero completion time is incomplete")
+		assert.Nil(t, notes[1].CompletedAt)
+
+		assert.Equal(t, "Legacy", notes[2].Content)
+		assert.True(t, notes[2].Done)
+	})
+
+	t.Run("encodes the selected schema", func(t *testing.T) {
+		now := completedAt.Add(time.Hour)
+		encode := func(schema Schema, input NoteInput) string {
+			c := NewClient(WithSchema(schema), WithLogger(log))
+			data, err := json.Marshal(c.encode(input, now))
+			require.NoError(t, err)
+			return string(data)
+		}
+
+		assert.JSONEq(t, `{"content":"Legacy","done":true}`, encode(SchemaLegacy, NoteInput{Content: "Legacy", Done: true}))
+		assert.JSONEq(t, `{"title":"Task","description":"D","completed_at":"2024-03-05T10:00:00Z"}`,
+			encode(SchemaTask, NoteInput{Content: "Task", Description: "D", Done: true}), "completed now")
+		assert.JSONEq(t, `{"title":"Task","completed_at":"2024-03-05T09:00:00Z"}`,
+			encode(SchemaTask, NoteInput{Content: "Task", Done: true, CompletedAt: &completedAt}), "keeps completion time")
+		assert.JSONEq(t, `{"title":"Task","completed_at":null}`,
+			encode(SchemaTask, NoteInput{Content: "Task", CompletedAt: &completedAt}), "incomplete clears it")
+		assert.JSONEq(t, `{"content":"Both","title":"Both","completed_at":null}`, encode(SchemaAuto, NoteInput{Content: "Both"}))
+		assert.JSONEq(t, `{"content":"Due","priority":"A","due_at":"2024-03-05T09:00:00Z"}`,
+			encode(SchemaLegacy, NoteInput{Content: "Due", Priority: "A", DueAt: &completedAt}))
+		assert.JSONEq(t, `{"title":"Due","completed_at":null,"priority":"A","due_at":"2024-03-05T09:00:00Z"}`,
+			encode(SchemaTask, NoteInput{Content: "Due", Priority: "A", DueAt: &completedAt}))
+	})
+
+	t.Run("schema from env", func(t *testing.T) {
+		t.Setenv(envGodoAPISchema, "Task")
+		assert.Equal(t, SchemaTask, NewClient(WithLogger(log)).schema)
+	})
+
+	t.Run("detects the server schema", func(t *testing.T) {
+		var lastBody map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				fmt.Fprint(rw, `{"tasks":[{"id":"1","title":"Task","description":"Details"}]}`)
+				return
+			}
+			lastBody = nil
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&lastBody))
+			fmt.Fprint(rw, `{"id":"1","title":"Task","description":"Details","completed_at":"2024-03-05T09:00:00Z"}`)
+		}))
+		defer srv.Close()
+
+		w := New(log, WithBaseURL(srv.URL), WithCacheDir(""))
+		w.SetSize(60, 20)
+		w.Update(w.fetchNotes())
+		assert.Equal(t, SchemaTask, w.store.(*Client).detected)
+		assert.Contains(t, w.View(), "Details", "description in the detail pane")
+		assert.NotContains(t, w.View(), "Completed")
+
+		cmd := w.toggleNote("1")
+		assert.NotNil(t, w.notes[0].CompletedAt)
+		w.Update(cmd())
+		assert.Equal(t, "Task", lastBody["title"])
+		assert.Equal(t, "Details", lastBody["description"], "description is kept")
+		assert.NotContains(t, lastBody, "content")
+		assert.NotNil(t, lastBody["completed_at"])
+		assert.Contains(t, w.View(), "Completed")
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notes.json")
+		s := NewFileStore(path)
+
+		notes, err := s.ListNotes()
+		require.NoError(t, err)
+		assert.Empty(t, notes, "missing file is empty")
+
+		created, err := s.CreateNote(NoteInput{Content: "First", Description: "Details"})
+		require.NoError(t, err)
+		_, err = s.CreateNote(NoteInput{Content: "Second"})
+		require.NoError(t, err)
+
+		updated, err := s.UpdateNote(created.ID, NoteInput{Content: "First", Description: "Details", Done: true})
+		require.NoError(t, err)
+		assert.NotNil(t, updated.CompletedAt)
+
+		_, err = s.UpdateNote("missing", NoteInput{Content: "x"})
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		notes, err = NewFileStore(path).ListNotes()
+		require.NoError(t, err)
+		require.Len(t, notes, 2)
+		assert.Equal(t, created.ID, notes[0].ID)
+		assert.True(t, notes[0].Done)
+		assert.Equal(t, "Details", notes[0].Description)
+
+		require.NoError(t, s.DeleteNote(notes[1].ID))
+		assert.ErrorIs(t, s.DeleteNote(notes[1].ID), ErrNotFound)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "TODO.md")
+		original := "# Todo\n\n- [ ] Buy milk\n  Semi-skimmed\n  Two litres\n- [x] Call Sam\n* [ ] Buy milk\n\nSome notes\n"
+		require.NoError(t, os.WriteFile(path, []byte(original), 0o644))
+		s := NewFileStore(path)
+
+		notes, err := s.ListNotes()
+		require.NoError(t, err)
+		require.Len(t, notes, 3)
+		assert.Equal(t, "Buy milk", notes[0].Content)
+		assert.Equal(t, "Semi-skimmed\nTwo litres", notes[0].Description)
+		assert.True(t, notes[1].Done)
+		assert.NotEqual(t, notes[0].ID, notes[2].ID, "repeated content gets distinct IDs")
+
+		updated, err := s.UpdateNote(notes[2].ID, NoteInput{Content: "Buy bread", Done: true})
+		require.NoError(t, err)
+		assert.Equal(t, "Buy bread", updated.Content)
+		_, err = s.CreateNote(NoteInput{Content: "New item"})
+		require.NoError(t, err)
+		require.NoError(t, s.DeleteNote(notes[1].ID))
+
+		_, err = s.CreateNote(NoteInput{Content: "two\nlines"})
+		assert.ErrorIs(t, err, ErrValidation)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "# Todo\n\n- [ ] Buy milk\n  Semi-skimmed\n  Two litres\n* [x] Buy bread\n\nSome notes\n- [ ] New item\n", string(data),
+			"other lines are preserved")
+	})
+
+	t.Run("markdown priority and due date", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "TODO.md")
+		require.NoError(t, os.WriteFile(path, []byte("- [ ] Pay rent due:2024-04-01 (A)\n"), 0o644))
+		s := NewFileStore(path)
+
+		notes, err := s.ListNotes()
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		assert.Equal(t, "Pay rent", notes[0].Content)
+		assert.Equal(t, "A", notes[0].Priority)
+		require.NotNil(t, notes[0].DueAt)
+		assert.Equal(t, "2024-04-01", notes[0].DueAt.Format(todoDate))
+
+		due := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+		updated, err := s.UpdateNote(notes[0].ID, NoteInput{Content: "Pay rent", Priority: "B", DueAt: &due})
+		require.NoError(t, err)
+		assert.Equal(t, notes[0].ID, updated.ID, "markers are not part of the ID")
+		assert.Equal(t, "B", updated.Priority)
+		_, err = s.CreateNote(NoteInput{Content: "Plain"})
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "- [ ] Pay rent (B) due:2024-05-01\n- [ ] Plain\n", string(data))
+	})
+
+	t.Run("todo.txt", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "todo.txt")
+		original := "(A) 2024-03-01 Call Mom +family @phone\n\nx 2024-03-05 2024-03-02 File taxes +admin pri:B\nBuy milk @shop due:2024-04-01\n"
+		require.NoError(t, os.WriteFile(path, []byte(original), 0o644))
+		s := NewFileStore(path)
+
+		notes, err := s.ListNotes()
+		require.NoError(t, err)
+		require.Len(t, notes, 3)
+		assert.Equal(t, "Call Mom +family @phone", notes[0].Content)
+		assert.Equal(t, "A", notes[0].Priority)
+		assert.Equal(t, []string{"+family", "@phone"}, notes[0].Tags)
+		assert.Equal(t, "2024-03-01", notes[0].CreatedAt.Format(todoDate))
+		assert.True(t, notes[1].Done)
+		assert.Equal(t, "B", notes[1].Priority, "completed tasks keep their priority")
+		require.NotNil(t, notes[1].CompletedAt)
+		assert.Equal(t, "2024-03-05", notes[1].CompletedAt.Format(todoDate))
+		require.NotNil(t, notes[2].DueAt)
+		assert.Equal(t, "2024-04-01", notes[2].DueAt.Format(todoDate))
+
+		// Completing keeps the ID, which follows the text
+		done, err := s.UpdateNote(notes[0].ID, NoteInput{Content: notes[0].Content, Done: true, Priority: "A"})
+		require.NoError(t, err)
+		assert.Equal(t, notes[0].ID, done.ID)
+		_, err = s.UpdateNote(notes[1].ID, NoteInput{Content: notes[1].Content, Priority: "B"})
+		require.NoError(t, err)
+		created, err := s.CreateNote(NoteInput{Content: "(C) Water plants +home"})
+		require.NoError(t, err)
+		assert.Equal(t, "C", created.Priority)
+		assert.Equal(t, "Water plants +home", created.Content)
+
+		t.Run("changing the due date", func(t *testing.T) {
+			later := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+			moved, err := s.UpdateNote(notes[2].ID, NoteInput{Content: notes[2].Content, DueAt: &later})
+			require.NoError(t, err)
+			assert.Equal(t, notes[2].ID, moved.ID, "rescheduling keeps the ID")
+			assert.Equal(t, "Buy milk @shop due:2024-05-01", moved.Content)
+			require.NotNil(t, moved.DueAt)
+			assert.Equal(t, "2024-05-01", moved.DueAt.Format(todoDate))
+		})
+
+		t.Run("editing the due tag", func(t *testing.T) {
+			later := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+			edited, err := s.UpdateNote(notes[2].ID, NoteInput{Content: "Buy milk @shop due:2024-05-03", DueAt: &later})
+			require.NoError(t, err)
+			assert.Equal(t, "2024-05-03", edited.DueAt.Format(todoDate))
+			_, err = s.UpdateNote(notes[2].ID, NoteInput{Content: "Buy milk @shop due:2024-05-01", DueAt: &later})
+			require.NoError(t, err)
+		})
+
+		t.Run("clearing the due date", func(t *testing.T) {
+			cleared, err := s.UpdateNote(notes[2].ID, NoteInput{Content: "Buy milk @shop due:2024-05-01"})
+			require.NoError(t, err)
+			assert.Equal(t, "Buy milk @shop", cleared.Content)
+			assert.Nil(t, cleared.DueAt)
+		})
+		require.NoError(t, s.DeleteNote(notes[2].ID))
+
+		today := time.Now().Format(todoDate)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "x "+today+" 2024-03-01 Call Mom +family @phone pri:A\n\n(B) 2024-03-02 File taxes +admin\n(C) "+today+" Water plants +home\n", string(data))
+	})
+
+	t.Run("widget filters by tag", func(t *testing.T) {
+		log, _ := testlogger.NewTestLogger(t, "notes-tag-test")
+		path := filepath.Join(t.TempDir(), "todo.txt")
+		require.NoError(t, os.WriteFile(path, []byte("(A) Call Mom +family\nBuy milk @shop\nBake cake +family @shop\n"), 0o644))
+		w := NewWithStore(log, NewFileStore(path))
+		w.Focus()
+		w.SetSize(80, 24)
+		w.Update(w.fetchNotes())
+		assert.Contains(t, w.View(), "(A)")
+
+		press := func(key string) {
+			w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+		press("j")
+		press("j")
+		press("t")
+		assert.Equal(t, "+family", w.tagFilter)
+		note, ok := w.current()
+		require.True(t, ok)
+		assert.Equal(t, "Bake cake +family @shop", note.Content, "selection follows the note")
+		assert.NotContains(t, w.View(), "Buy milk")
+
+		press("k")
+		press(" ")
+		assert.True(t, w.notes[0].Done, "actions apply to the visible note")
+
+		press("t")
+		assert.Equal(t, "@shop", w.tagFilter)
+		press("t")
+		assert.Empty(t, w.tagFilter)
+		assert.Contains(t, w.View(), "Buy milk")
+	})
+
+	t.Run("widget follows IDs derived from content", func(t *testing.T) {
+		log, _ := testlogger.NewTestLogger(t, "notes-rename-test")
+		path := filepath.Join(t.TempDir(), "notes.md")
+		require.NoError(t, os.WriteFile(path, []byte("- [ ] First\n- [ ] Second\n"), 0o644))
+		w := NewWithStore(log, NewFileStore(path))
+		w.Focus()
+		w.SetSize(80, 24)
+		w.Update(w.fetchNotes())
+		id := w.notes[0].ID
+		w.selectID(id)
+
+		// Two edits sent before the first reply arrives
+		first := w.updateNote(id, "First, edited")()
+		second := w.updateNote(id, "First, edited twice")()
+
+		w.Update(first)
+		renamed := w.notes[0].ID
+		assert.NotEqual(t, id, renamed)
+		assert.Equal(t, renamed, w.selectedID)
+		assert.Equal(t, 1, w.pending[renamed])
+		assert.Equal(t, "First, edited twice", w.notes[0].Content, "newer local change kept")
+
+		_, cmd := w.Update(second)
+		require.NotNil(t, cmd, "the edit is sent again under the new ID")
+		w.Update(cmd())
+		assert.Empty(t, w.toast)
+		assert.Empty(t, w.pending)
+		assert.Empty(t, w.renamed)
+		note, ok := w.current()
+		require.True(t, ok)
+		assert.Equal(t, "First, edited twice", note.Content)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "- [ ] First, edited twice\n- [ ] Second\n", string(data))
+	})
+
+	t.Run("widget reloads on external edits", func(t *testing.T) {
+		log, _ := testlogger.NewTestLogger(t, "notes-file-test")
+		path := filepath.Join(t.TempDir(), "notes.md")
+		t.Setenv(envNotesFile, path)
+		w := New(log)
+		_, ok := w.store.(*FileStore)
+		require.True(t, ok, "file store selected by env")
+
+		w.Update(w.fetchNotes())
+		_, cmd := w.Update(watchMsg{fingerprint: w.store.(*FileStore).Fingerprint()})
+		assert.NotNil(t, cmd)
+		assert.Contains(t, w.View(), "No notes")
+
+		require.NoError(t, os.WriteFile(path, []byte("- [ ] Edited by hand\n"), 0o644))
+		msg := watchMsg{fingerprint: w.store.(*FileStore).Fingerprint()}
+		assert.NotEqual(t, w.fingerprint, msg.fingerprint)
+
+		w.Update(w.fetchNotes())
+		assert.Contains(t, w.View(), "Edited by hand")
+	})
 }
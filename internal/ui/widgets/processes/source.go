@@ -0,0 +1,109 @@
+package processes
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Info is a snapshot of a single process for the table
+type Info struct {
+	PID     int32
+	User    string
+	Command string
+	RSS     uint64
+	// CPUTime is the total user and system CPU time in seconds
+	CPUTime float64
+}
+
+// Details holds the extended information shown in the details pane
+type Details struct {
+	PID       int32
+	Cmdline   string
+	Cwd       string
+	OpenFiles int32
+	Threads   int32
+}
+
+// Source provides process information and control. It is an interface so
+// the widget can be tested without touching real processes.
+type Source interface {
+	Processes() ([]Info, error)
+	Details(pid int32) (Details, error)
+	Signal(pid int32, sig syscall.Signal) error
+}
+
+// systemSource implements Source using gopsutil
+type systemSource struct{}
+
+// NewSystemSource creates a Source backed by the operating system
+func NewSystemSource() Source {
+	return systemSource{}
+}
+
+// Processes implements Source. Processes that exit while being read, or whose
+// attributes cannot be read, are reported with the fields that are available.
+func (systemSource) Processes() ([]Info, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	infos := make([]Info, 0, len(procs))
+	for _, p := range procs {
+		name, err := p.Name()
+		if err != nil {
+			// Most likely the process exited
+			continue
+		}
+
+		info := Info{PID: p.Pid, Command: name}
+		if user, err := p.Username(); err == nil {
+			info.User = user
+		}
+		if mem, err := p.MemoryInfo(); err == nil && mem != nil {
+			info.RSS = mem.RSS
+		}
+		if times, err := p.Times(); err == nil && times != nil {
+			info.CPUTime = times.User + times.System
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Details implements Source
+func (systemSource) Details(pid int32) (Details, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return Details{}, fmt.Errorf("failed to open process %d: %w", pid, err)
+	}
+
+	details := Details{PID: pid}
+	if cmdline, err := p.Cmdline(); err == nil {
+		details.Cmdline = cmdline
+	}
+	if cwd, err := p.Cwd(); err == nil {
+		details.Cwd = cwd
+	}
+	if fds, err := p.NumFDs(); err == nil {
+		details.OpenFiles = fds
+	}
+	if threads, err := p.NumThreads(); err == nil {
+		details.Threads = threads
+	}
+	return details, nil
+}
+
+// Signal implements Source
+func (systemSource) Signal(pid int32, sig syscall.Signal) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return fmt.Errorf("failed to open process %d: %w", pid, err)
+	}
+	if err := p.SendSignal(sig); err != nil {
+		return fmt.Errorf("failed to send %s to process %d: %w", sig, pid, err)
+	}
+	return nil
+}
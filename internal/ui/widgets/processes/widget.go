@@ -0,0 +1,484 @@
+// Package processes provides a top-like widget for listing and managing processes
+package processes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/logger"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+const (
+	defaultInterval = 3 * time.Second
+	// chromeHeight is the number of lines used by borders, padding, title,
+	// table header and help text
+	chromeHeight = 10
+)
+
+// sortMode selects the column the table is ordered by
+type sortMode int
+
+const (
+	sortCPU sortMode = iota
+	sortMemory
+)
+
+// String returns the display name of the sort mode
+func (s sortMode) String() string {
+	if s == sortMemory {
+		return "memory"
+	}
+	return "CPU"
+}
+
+// row is a process with its CPU usage over the last interval
+type row struct {
+	Info
+	cpuPercent float64
+}
+
+// pendingSignal is a signal awaiting confirmation
+type pendingSignal struct {
+	pid  int32
+	name string
+	sig  syscall.Signal
+}
+
+// Widget represents the process table widget
+type Widget struct {
+	components.BaseWidget
+	source   Source
+	logger   logger.Logger
+	interval time.Duration
+
+	rows      []row
+	visible   []row
+	prevTimes map[int32]float64
+	prevAt    time.Time
+
+	sortBy      sortMode
+	filter      textinput.Model
+	filtering   bool
+	selected    int
+	selectedPID int32
+	offset      int
+
+	details   *Details
+	confirm   *pendingSignal
+	status    string
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithSource sets the process source, mainly for tests
+func WithSource(source Source) Option {
+	return func(w *Widget) {
+		w.source = source
+	}
+}
+
+// WithInterval sets the refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new process widget
+func New(log logger.Logger, opts ...Option) *Widget {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter by name"
+
+	w := &Widget{
+		source:    NewSystemSource(),
+		logger:    log,
+		interval:  defaultInterval,
+		prevTimes: make(map[int32]float64),
+		filter:    filter,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.fetchProcesses
+}
+
+// CapturingInput implements components.InputCapturer
+func (w *Widget) CapturingInput() bool {
+	return w.filtering || w.confirm != nil
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		return w, w.handleKey(msg)
+	case processesMsg:
+		if msg.err != nil {
+			w.lastError = msg.err
+		} else {
+			w.lastError = nil
+			w.updateRows(msg.infos, msg.sampledAt)
+		}
+		if msg.manual {
+			// The interval refresh is already scheduled
+			return w, nil
+		}
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.fetchProcesses
+	case detailsMsg:
+		if msg.err != nil {
+			w.lastError = msg.err
+			return w, nil
+		}
+		w.details = &msg.details
+	case signalResultMsg:
+		if msg.err != nil {
+			w.logger.Error("Failed to signal process",
+				logger.NewField("pid", msg.pid),
+				logger.NewField("signal", msg.sig.String()),
+				logger.NewField("error", msg.err),
+			)
+			w.lastError = msg.err
+			return w, nil
+		}
+		w.logger.Info("Signaled process",
+			logger.NewField("pid", msg.pid),
+			logger.NewField("signal", msg.sig.String()),
+		)
+		w.status = fmt.Sprintf("Sent %s to %d", signalName(msg.sig), msg.pid)
+		return w, w.fetchManual
+	}
+	return w, nil
+}
+
+// handleKey processes a key press while focused
+func (w *Widget) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if w.confirm != nil {
+		pending := *w.confirm
+		w.confirm = nil
+		if msg.String() == "y" {
+			return w.sendSignal(pending.pid, pending.sig)
+		}
+		w.status = "Cancelled"
+		return nil
+	}
+
+	if w.filtering {
+		switch msg.String() {
+		case "esc":
+			w.filter.SetValue("")
+			w.stopFiltering()
+		case "enter":
+			w.stopFiltering()
+		default:
+			var cmd tea.Cmd
+			w.filter, cmd = w.filter.Update(msg)
+			w.refreshVisible()
+			return cmd
+		}
+		w.refreshVisible()
+		return nil
+	}
+
+	if w.details != nil {
+		if msg.String() == "esc" || msg.String() == "enter" {
+			w.details = nil
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		w.moveSelection(-1)
+	case "down", "j":
+		w.moveSelection(1)
+	case "s":
+		w.sortBy = (w.sortBy + 1) % 2
+		w.refreshVisible()
+	case "/":
+		w.filtering = true
+		return w.filter.Focus()
+	case "esc":
+		w.filter.SetValue("")
+		w.refreshVisible()
+	case "enter":
+		if p, ok := w.current(); ok {
+			return w.fetchDetails(p.PID)
+		}
+	case "x":
+		w.requestSignal(syscall.SIGTERM)
+	case "X":
+		w.requestSignal(syscall.SIGKILL)
+	}
+	return nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	b.WriteString(styles.Title.Render(fmt.Sprintf("Processes (%d, by %s)", len(w.visible), w.sortBy)))
+	b.WriteString("\n")
+
+	if w.filtering || w.filter.Value() != "" {
+		b.WriteString(w.filter.View())
+	}
+	b.WriteString("\n")
+
+	if w.details != nil {
+		w.renderDetails(&b)
+	} else {
+		w.renderTable(&b, max(height-chromeHeight, 1))
+	}
+
+	b.WriteString("\n")
+	switch {
+	case w.confirm != nil:
+		warn := lipgloss.NewStyle().Foreground(styles.Secondary).Bold(true)
+		b.WriteString(warn.Render(fmt.Sprintf("Send %s to %d (%s)? y/n",
+			signalName(w.confirm.sig), w.confirm.pid, w.confirm.name)))
+	case w.lastError != nil:
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+	case w.status != "":
+		b.WriteString(subtle.Render(w.status))
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("↑/↓: select • s: sort • /: filter • enter: details • x/X: term/kill"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderTable writes at most maxRows processes, scrolled to keep the selection visible
+func (w *Widget) renderTable(b *strings.Builder, maxRows int) {
+	b.WriteString(styles.Title.Render(fmt.Sprintf("%7s %-10s %6s %10s %s", "PID", "USER", "CPU%", "RSS", "COMMAND")))
+	b.WriteString("\n")
+
+	if len(w.visible) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render("No processes"))
+		return
+	}
+
+	if w.selected < w.offset {
+		w.offset = w.selected
+	} else if w.selected >= w.offset+maxRows {
+		w.offset = w.selected - maxRows + 1
+	}
+
+	end := min(w.offset+maxRows, len(w.visible))
+	for i := w.offset; i < end; i++ {
+		p := w.visible[i]
+		line := fmt.Sprintf("%7d %-10s %6.1f %10s %s",
+			p.PID, format.Truncate(p.User, 10), p.cpuPercent, format.Bytes(p.RSS), p.Command)
+
+		style := lipgloss.NewStyle()
+		if i == w.selected && w.IsFocused() {
+			style = styles.Selected
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+}
+
+// renderDetails writes the details pane for the selected process
+func (w *Widget) renderDetails(b *strings.Builder) {
+	d := w.details
+	b.WriteString(styles.Title.Render(fmt.Sprintf("PID %d", d.PID)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("cmdline: %s\n", d.Cmdline))
+	b.WriteString(fmt.Sprintf("cwd:     %s\n", d.Cwd))
+	b.WriteString(fmt.Sprintf("files:   %d\n", d.OpenFiles))
+	b.WriteString(fmt.Sprintf("threads: %d\n", d.Threads))
+	b.WriteString(lipgloss.NewStyle().Foreground(styles.Subtle).Render("esc: back"))
+}
+
+// updateRows computes CPU usage from the previous sample and refreshes the view
+func (w *Widget) updateRows(infos []Info, sampledAt time.Time) {
+	elapsed := sampledAt.Sub(w.prevAt).Seconds()
+	times := make(map[int32]float64, len(infos))
+
+	w.rows = w.rows[:0]
+	for _, info := range infos {
+		r := row{Info: info}
+		if prev, ok := w.prevTimes[info.PID]; ok && elapsed > 0 && info.CPUTime >= prev {
+			r.cpuPercent = (info.CPUTime - prev) / elapsed * 100
+		}
+		times[info.PID] = info.CPUTime
+		w.rows = append(w.rows, r)
+	}
+
+	w.prevTimes = times
+	w.prevAt = sampledAt
+	w.refreshVisible()
+}
+
+// refreshVisible applies the filter and sort order, keeping the selected PID
+func (w *Widget) refreshVisible() {
+	query := strings.ToLower(w.filter.Value())
+
+	w.visible = w.visible[:0]
+	for _, r := range w.rows {
+		if query == "" || strings.Contains(strings.ToLower(r.Command), query) {
+			w.visible = append(w.visible, r)
+		}
+	}
+
+	sort.SliceStable(w.visible, func(i, j int) bool {
+		a, b := w.visible[i], w.visible[j]
+		if w.sortBy == sortMemory {
+			if a.RSS != b.RSS {
+				return a.RSS > b.RSS
+			}
+		} else if a.cpuPercent != b.cpuPercent {
+			return a.cpuPercent > b.cpuPercent
+		}
+		return a.PID < b.PID
+	})
+
+	w.selected = 0
+	for i, r := range w.visible {
+		if r.PID == w.selectedPID {
+			w.selected = i
+			break
+		}
+	}
+	if p, ok := w.current(); ok {
+		w.selectedPID = p.PID
+	}
+}
+
+// moveSelection moves the cursor by delta rows within bounds
+func (w *Widget) moveSelection(delta int) {
+	next := w.selected + delta
+	if next < 0 || next >= len(w.visible) {
+		return
+	}
+	w.selected = next
+	w.selectedPID = w.visible[next].PID
+}
+
+// current returns the selected process
+func (w *Widget) current() (row, bool) {
+	if w.selected < 0 || w.selected >= len(w.visible) {
+		return row{}, false
+	}
+	return w.visible[w.selected], true
+}
+
+// requestSignal asks for confirmation before signaling the selected process
+func (w *Widget) requestSignal(sig syscall.Signal) {
+	p, ok := w.current()
+	if !ok {
+		return
+	}
+	w.status = ""
+	w.lastError = nil
+	w.confirm = &pendingSignal{pid: p.PID, name: p.Command, sig: sig}
+}
+
+// stopFiltering leaves filter input mode
+func (w *Widget) stopFiltering() {
+	w.filtering = false
+	w.filter.Blur()
+}
+
+// Message types for the process widget
+type processesMsg struct {
+	infos     []Info
+	sampledAt time.Time
+	err       error
+	// manual is set for refreshes outside the interval, such as after a signal
+	manual bool
+}
+
+type refreshMsg struct{}
+
+type detailsMsg struct {
+	details Details
+	err     error
+}
+
+type signalResultMsg struct {
+	pid int32
+	sig syscall.Signal
+	err error
+}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) fetchProcesses() tea.Msg {
+	infos, err := w.source.Processes()
+	return processesMsg{infos: infos, sampledAt: time.Now(), err: err}
+}
+
+func (w *Widget) fetchManual() tea.Msg {
+	msg := w.fetchProcesses().(processesMsg)
+	msg.manual = true
+	return msg
+}
+
+func (w *Widget) fetchDetails(pid int32) tea.Cmd {
+	source := w.source
+	return func() tea.Msg {
+		details, err := source.Details(pid)
+		return detailsMsg{details: details, err: err}
+	}
+}
+
+func (w *Widget) sendSignal(pid int32, sig syscall.Signal) tea.Cmd {
+	source := w.source
+	return func() tea.Msg {
+		return signalResultMsg{pid: pid, sig: sig, err: source.Signal(pid, sig)}
+	}
+}
+
+// signalName returns the conventional name of the supported signals
+func signalName(sig syscall.Signal) string {
+	switch sig {
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	case syscall.SIGKILL:
+		return "SIGKILL"
+	}
+	return sig.String()
+}
@@ -0,0 +1,177 @@
+package processes
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jonesrussell/dashboard/internal/testutil/testlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource implements Source for tests
+type fakeSource struct {
+	infos   []Info
+	details Details
+	signals []syscall.Signal
+	err     error
+}
+
+func (f *fakeSource) Processes() ([]Info, error) { return f.infos, f.err }
+
+func (f *fakeSource) Details(pid int32) (Details, error) {
+	d := f.details
+	d.PID = pid
+	return d, f.err
+}
+
+func (f *fakeSource) Signal(pid int32, sig syscall.Signal) error {
+	f.signals = append(f.signals, sig)
+	return f.err
+}
+
+func key(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func newTestWidget(t *testing.T, source *fakeSource) *Widget {
+	log, _ := testlogger.NewTestLogger(t, "processes-test")
+	w := New(log, WithSource(source))
+	w.SetSize(100, 40)
+	w.Focus()
+
+	now := time.Now()
+	w.Update(processesMsg{infos: source.infos, sampledAt: now})
+	next := make([]Info, len(source.infos))
+	copy(next, source.infos)
+	next[1].CPUTime += 1 // bash used one second of CPU over two seconds
+	w.Update(processesMsg{infos: next, sampledAt: now.Add(2 * time.Second)})
+	return w
+}
+
+func testSource() *fakeSource {
+	return &fakeSource{
+		infos: []Info{
+			{PID: 1, User: "root", Command: "init", RSS: 1024},
+			{PID: 42, User: "dev", Command: "bash", RSS: 512},
+			{PID: 99, User: "dev", Command: "postgres", RSS: 4096},
+		},
+		details: Details{Cmdline: "/bin/bash -l", Cwd: "/home/dev", OpenFiles: 4, Threads: 1},
+	}
+}
+
+func TestProcessWidget(t *testing.T) {
+	t.Run("sorts by cpu then memory", func(t *testing.T) {
+		w := newTestWidget(t, testSource())
+		require.Len(t, w.visible, 3)
+		assert.Equal(t, int32(42), w.visible[0].PID)
+		assert.InDelta(t, 50, w.visible[0].cpuPercent, 0.01)
+
+		assert.Equal(t, int32(1), w.visible[w.selected].PID, "selection kept from first sample")
+
+		w.Update(key("s"))
+		assert.Equal(t, sortMemory, w.sortBy)
+		assert.Equal(t, int32(99), w.visible[0].PID)
+		assert.Equal(t, 1, w.selected, "selection follows the PID across re-sorts")
+		assert.Equal(t, int32(1), w.visible[w.selected].PID)
+	})
+
+	t.Run("incremental filter", func(t *testing.T) {
+		w := newTestWidget(t, testSource())
+		w.Update(key("/"))
+		assert.True(t, w.CapturingInput())
+
+		w.Update(key("p"))
+		w.Update(key("o"))
+		require.Len(t, w.visible, 1)
+		assert.Equal(t, "postgres", w.visible[0].Command)
+
+		w.Update(key("enter"))
+		assert.False(t, w.CapturingInput())
+		assert.Len(t, w.visible, 1, "filter stays applied")
+
+		w.Update(key("esc"))
+		assert.Len(t, w.visible, 3)
+	})
+
+	t.Run("details pane", func(t *testing.T) {
+		w := newTestWidget(t, testSource())
+		w.Update(key("k"))
+		_, cmd := w.Update(key("enter"))
+		require.NotNil(t, cmd)
+		w.Update(cmd())
+
+		view := w.View()
+		assert.Contains(t, view, "PID 42")
+		assert.Contains(t, view, "/bin/bash -l")
+		assert.Contains(t, view, "threads: 1")
+
+		w.Update(key("esc"))
+		assert.Nil(t, w.details)
+	})
+
+	t.Run("kill requires confirmation", func(t *testing.T) {
+		source := testSource()
+		w := newTestWidget(t, source)
+		w.Update(key("k"))
+
+		_, cmd := w.Update(key("X"))
+		assert.Nil(t, cmd)
+		assert.True(t, w.CapturingInput())
+		assert.Contains(t, w.View(), "Send SIGKILL to 42 (bash)? y/n")
+
+		_, cmd = w.Update(key("n"))
+		assert.Nil(t, cmd)
+		assert.Empty(t, source.signals)
+
+		w.Update(key("x"))
+		_, cmd = w.Update(key("y"))
+		require.NotNil(t, cmd)
+		w.Update(cmd())
+		assert.Equal(t, []syscall.Signal{syscall.SIGTERM}, source.signals)
+		assert.Contains(t, w.View(), "Sent SIGTERM to 42")
+	})
+
+	t.Run("signals do not start extra refresh loops", func(t *testing.T) {
+		w := newTestWidget(t, testSource())
+		ticks := 0
+		for i := 0; i < 2; i++ {
+			_, cmd := w.Update(signalResultMsg{pid: 42, sig: syscall.SIGTERM})
+			require.NotNil(t, cmd, "expected refresh")
+			if _, next := w.Update(cmd()); next != nil {
+				ticks++
+			}
+		}
+		assert.Zero(t, ticks, "the interval refresh is already scheduled")
+
+		_, cmd := w.Update(w.fetchProcesses())
+		assert.NotNil(t, cmd, "interval refreshes still schedule the next tick")
+	})
+
+	t.Run("errors are shown", func(t *testing.T) {
+		source := testSource()
+		w := newTestWidget(t, source)
+		source.err = errors.New("operation not permitted")
+
+		w.Update(key("x"))
+		_, cmd := w.Update(key("y"))
+		w.Update(cmd())
+		assert.Contains(t, w.View(), "operation not permitted")
+	})
+
+	t.Run("ignores keys when not focused", func(t *testing.T) {
+		w := newTestWidget(t, testSource())
+		w.Blur()
+		w.Update(key("s"))
+		assert.Equal(t, sortCPU, w.sortBy)
+	})
+}
@@ -0,0 +1,212 @@
+package sensors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// DefaultSysfsRoot is where Linux mounts sysfs
+const DefaultSysfsRoot = "/sys"
+
+// Unit conversions for sysfs values
+const (
+	milliPerUnit = 1000.0
+	microPerUnit = 1000000.0
+)
+
+// Temperature is a temperature sensor reading in degrees Celsius
+type Temperature struct {
+	Label    string
+	Celsius  float64
+	High     float64
+	Critical float64
+}
+
+// Fan is a fan speed reading
+type Fan struct {
+	Label string
+	RPM   float64
+}
+
+// Battery is the state of a battery power supply
+type Battery struct {
+	Name     string
+	Capacity float64 // percent
+	Status   string  // e.g. Charging, Discharging, Full
+	// PowerWatts is the current power draw, zero when unknown
+	PowerWatts float64
+}
+
+// Snapshot holds one reading of all sensors
+type Snapshot struct {
+	Temperatures []Temperature
+	Fans         []Fan
+	Batteries    []Battery
+}
+
+// Reader reads sensors from sysfs below a configurable root so it can be
+// pointed at a fixture tree in tests
+type Reader struct {
+	SysfsRoot string
+	// UseHost enables the gopsutil fallback for temperatures when hwmon
+	// reports none, e.g. on non-Linux systems
+	UseHost bool
+}
+
+// Read returns the current sensor values. Missing sysfs directories are not
+// an error; a system without sensors yields an empty snapshot.
+func (r Reader) Read() (Snapshot, error) {
+	var snap Snapshot
+
+	chips, err := filepath.Glob(filepath.Join(r.SysfsRoot, "class", "hwmon", "*"))
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to list hwmon devices: %w", err)
+	}
+	sort.Strings(chips)
+	for _, chip := range chips {
+		temps, fans := readHwmon(chip)
+		snap.Temperatures = append(snap.Temperatures, temps...)
+		snap.Fans = append(snap.Fans, fans...)
+	}
+
+	if len(snap.Temperatures) == 0 && r.UseHost {
+		snap.Temperatures = hostTemperatures()
+	}
+
+	supplies, err := filepath.Glob(filepath.Join(r.SysfsRoot, "class", "power_supply", "*"))
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to list power supplies: %w", err)
+	}
+	sort.Strings(supplies)
+	for _, supply := range supplies {
+		if battery, ok := readBattery(supply); ok {
+			snap.Batteries = append(snap.Batteries, battery)
+		}
+	}
+
+	return snap, nil
+}
+
+// readHwmon reads the temperature and fan inputs of a single hwmon chip
+func readHwmon(dir string) ([]Temperature, []Fan) {
+	chip := readString(filepath.Join(dir, "name"))
+	if chip == "" {
+		chip = filepath.Base(dir)
+	}
+
+	var temps []Temperature
+	inputs, _ := filepath.Glob(filepath.Join(dir, "temp*_input"))
+	sort.Strings(inputs)
+	for _, input := range inputs {
+		prefix := strings.TrimSuffix(input, "_input")
+		milli, ok := readFloat(input)
+		if !ok {
+			continue
+		}
+		high, _ := readFloat(prefix + "_max")
+		crit, _ := readFloat(prefix + "_crit")
+		temps = append(temps, Temperature{
+			Label:    sensorLabel(chip, prefix),
+			Celsius:  milli / milliPerUnit,
+			High:     high / milliPerUnit,
+			Critical: crit / milliPerUnit,
+		})
+	}
+
+	var fans []Fan
+	inputs, _ = filepath.Glob(filepath.Join(dir, "fan*_input"))
+	sort.Strings(inputs)
+	for _, input := range inputs {
+		rpm, ok := readFloat(input)
+		if !ok {
+			continue
+		}
+		fans = append(fans, Fan{
+			Label: sensorLabel(chip, strings.TrimSuffix(input, "_input")),
+			RPM:   rpm,
+		})
+	}
+
+	return temps, fans
+}
+
+// readBattery reads a power_supply entry, reporting false for non-batteries
+func readBattery(dir string) (Battery, bool) {
+	if readString(filepath.Join(dir, "type")) != "Battery" {
+		return Battery{}, false
+	}
+
+	battery := Battery{
+		Name:   filepath.Base(dir),
+		Status: readString(filepath.Join(dir, "status")),
+	}
+	battery.Capacity, _ = readFloat(filepath.Join(dir, "capacity"))
+
+	// power_now is in microwatts; some drivers only expose current and voltage
+	if power, ok := readFloat(filepath.Join(dir, "power_now")); ok {
+		battery.PowerWatts = power / microPerUnit
+	} else {
+		current, okCurrent := readFloat(filepath.Join(dir, "current_now"))
+		voltage, okVoltage := readFloat(filepath.Join(dir, "voltage_now"))
+		if okCurrent && okVoltage {
+			battery.PowerWatts = (current / microPerUnit) * (voltage / microPerUnit)
+		}
+	}
+
+	return battery, true
+}
+
+// hostTemperatures reads temperatures through gopsutil
+func hostTemperatures() []Temperature {
+	stats, err := host.SensorsTemperatures()
+	if err != nil && len(stats) == 0 {
+		return nil
+	}
+
+	temps := make([]Temperature, 0, len(stats))
+	for _, s := range stats {
+		temps = append(temps, Temperature{
+			Label:    s.SensorKey,
+			Celsius:  s.Temperature,
+			High:     s.High,
+			Critical: s.Critical,
+		})
+	}
+	return temps
+}
+
+// sensorLabel names a sensor after its chip and optional *_label file
+func sensorLabel(chip, prefix string) string {
+	if label := readString(prefix + "_label"); label != "" {
+		return chip + " " + label
+	}
+	return chip + " " + filepath.Base(prefix)
+}
+
+// readString reads a trimmed sysfs attribute, returning "" if unavailable
+func readString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readFloat reads a numeric sysfs attribute
+func readFloat(path string) (float64, bool) {
+	s := readString(path)
+	if s == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
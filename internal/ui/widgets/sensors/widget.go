@@ -0,0 +1,183 @@
+// Package sensors provides a widget for temperature, fan and battery sensors
+package sensors
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	defaultInterval = 5 * time.Second
+	envSysfsRoot    = "DASHBOARD_SYSFS_ROOT"
+)
+
+// Widget represents the sensors widget
+type Widget struct {
+	components.BaseWidget
+	reader    Reader
+	interval  time.Duration
+	snapshot  Snapshot
+	loaded    bool
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithSysfsRoot reads sensors below root instead of /sys. The gopsutil
+// temperature fallback is disabled so only the given tree is used.
+func WithSysfsRoot(root string) Option {
+	return func(w *Widget) {
+		w.reader = Reader{SysfsRoot: root}
+	}
+}
+
+// WithInterval sets the refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new sensors widget. The sysfs root defaults to
+// DASHBOARD_SYSFS_ROOT when set.
+func New(opts ...Option) *Widget {
+	w := &Widget{
+		reader:   Reader{SysfsRoot: DefaultSysfsRoot, UseHost: true},
+		interval: defaultInterval,
+	}
+	if root := os.Getenv(envSysfsRoot); root != "" {
+		WithSysfsRoot(root)(w)
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.read
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case sensorsMsg:
+		w.lastError = msg.err
+		if msg.err == nil {
+			w.snapshot = msg.snapshot
+			w.loaded = true
+		}
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.read
+	}
+	return w, nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	b.WriteString(styles.Title.Render("Sensors"))
+	b.WriteString("\n")
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	switch {
+	case w.lastError != nil:
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+	case !w.loaded:
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("Loading..."))
+	case len(w.snapshot.Temperatures)+len(w.snapshot.Fans)+len(w.snapshot.Batteries) == 0:
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("No sensors found"))
+	default:
+		w.renderSnapshot(&b)
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderSnapshot writes the temperature, fan and battery sections
+func (w *Widget) renderSnapshot(b *strings.Builder) {
+	if len(w.snapshot.Temperatures) > 0 {
+		b.WriteString("\n")
+		b.WriteString(styles.Title.Render("Temperature"))
+		b.WriteString("\n")
+		for _, t := range w.snapshot.Temperatures {
+			value := lipgloss.NewStyle().Foreground(temperatureColor(t)).
+				Render(fmt.Sprintf("%5.1f°C", t.Celsius))
+			b.WriteString(fmt.Sprintf("%s %s\n", value, t.Label))
+		}
+	}
+
+	if len(w.snapshot.Fans) > 0 {
+		b.WriteString("\n")
+		b.WriteString(styles.Title.Render("Fans"))
+		b.WriteString("\n")
+		for _, f := range w.snapshot.Fans {
+			b.WriteString(fmt.Sprintf("%5.0f RPM %s\n", f.RPM, f.Label))
+		}
+	}
+
+	if len(w.snapshot.Batteries) > 0 {
+		b.WriteString("\n")
+		b.WriteString(styles.Title.Render("Battery"))
+		b.WriteString("\n")
+		for _, bat := range w.snapshot.Batteries {
+			line := fmt.Sprintf("%s %.0f%% %s", bat.Name, bat.Capacity, bat.Status)
+			if bat.PowerWatts > 0 {
+				line += fmt.Sprintf(" %.1f W", bat.PowerWatts)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+}
+
+// temperatureColor highlights readings at or above their high or critical marks
+func temperatureColor(t Temperature) lipgloss.Color {
+	switch {
+	case t.Critical > 0 && t.Celsius >= t.Critical:
+		return styles.Critical
+	case t.High > 0 && t.Celsius >= t.High:
+		return styles.Warning
+	}
+	return styles.Primary
+}
+
+// Message types for the sensors widget
+type sensorsMsg struct {
+	snapshot Snapshot
+	err      error
+}
+
+type refreshMsg struct{}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) read() tea.Msg {
+	snapshot, err := w.reader.Read()
+	return sensorsMsg{snapshot: snapshot, err: err}
+}
@@ -0,0 +1,78 @@
+package sensors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureRoot is a sysfs tree with two hwmon chips, two batteries and a mains supply
+var fixtureRoot = filepath.Join("testdata", "sys")
+
+func TestReader(t *testing.T) {
+	snap, err := Reader{SysfsRoot: fixtureRoot}.Read()
+	require.NoError(t, err)
+
+	t.Run("temperatures", func(t *testing.T) {
+		require.Len(t, snap.Temperatures, 2)
+		assert.Equal(t, Temperature{Label: "coretemp Package id 0", Celsius: 45, High: 80, Critical: 100},
+			snap.Temperatures[0])
+		assert.Equal(t, "coretemp temp2", snap.Temperatures[1].Label, "falls back to input name")
+		assert.InDelta(t, 85.5, snap.Temperatures[1].Celsius, 0.001)
+	})
+
+	t.Run("fans", func(t *testing.T) {
+		require.Len(t, snap.Fans, 1)
+		assert.Equal(t, Fan{Label: "thinkpad CPU fan", RPM: 2400}, snap.Fans[0])
+	})
+
+	t.Run("batteries", func(t *testing.T) {
+		require.Len(t, snap.Batteries, 2, "mains supplies are skipped")
+		assert.Equal(t, Battery{Name: "BAT0", Capacity: 85, Status: "Discharging", PowerWatts: 12.5},
+			snap.Batteries[0])
+		assert.InDelta(t, 24, snap.Batteries[1].PowerWatts, 0.001, "derived from current and voltage")
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		snap, err := Reader{SysfsRoot: filepath.Join(t.TempDir(), "none")}.Read()
+		require.NoError(t, err)
+		assert.Empty(t, snap.Temperatures)
+		assert.Empty(t, snap.Batteries)
+	})
+}
+
+func TestSensorsWidget(t *testing.T) {
+	t.Run("env root", func(t *testing.T) {
+		t.Setenv(envSysfsRoot, fixtureRoot)
+		w := New()
+		assert.Equal(t, Reader{SysfsRoot: fixtureRoot}, w.reader)
+	})
+
+	w := New(WithSysfsRoot(fixtureRoot))
+	w.SetSize(80, 40)
+	assert.Contains(t, w.View(), "Loading...")
+
+	_, cmd := w.Update(w.read())
+	assert.NotNil(t, cmd, "expected next tick")
+
+	view := w.View()
+	assert.Contains(t, view, "45.0°C coretemp Package id 0")
+	assert.Contains(t, view, "2400 RPM thinkpad CPU fan")
+	assert.Contains(t, view, "BAT0 85% Discharging 12.5 W")
+
+	t.Run("empty tree", func(t *testing.T) {
+		w := New(WithSysfsRoot(t.TempDir()))
+		w.SetSize(80, 40)
+		w.Update(w.read())
+		assert.Contains(t, w.View(), "No sensors found")
+	})
+}
+
+func TestTemperatureColor(t *testing.T) {
+	assert.Equal(t, styles.Primary, temperatureColor(Temperature{Celsius: 40, High: 80}))
+	assert.Equal(t, styles.Warning, temperatureColor(Temperature{Celsius: 85, High: 80, Critical: 100}))
+	assert.Equal(t, styles.Critical, temperatureColor(Temperature{Celsius: 100, High: 80, Critical: 100}))
+}
@@ -0,0 +1,197 @@
+package sysinfo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultCgroupRoot is where the cgroup filesystem is mounted
+	defaultCgroupRoot = "/sys/fs/cgroup"
+	// cgroupV1Unlimited is the smallest cgroup v1 memory limit treated as
+	// unlimited; the kernel reports unlimited as a page-aligned max int64
+	cgroupV1Unlimited = 1 << 62
+	// nsPerUsec converts cgroup v1 nanosecond counters to microseconds
+	nsPerUsec = 1000
+)
+
+// cgroupStats holds the limits and usage of the cgroup the dashboard runs in.
+// Zero limits mean the resource is not limited.
+type cgroupStats struct {
+	version int
+
+	memLimit uint64 // bytes
+	memUsage uint64 // bytes, excluding inactive file cache
+	memCache uint64 // bytes of page cache charged to the cgroup
+
+	cpuLimit float64 // cores
+	cpuUsage uint64  // cumulative CPU time in microseconds
+}
+
+// limited reports whether any resource is constrained by the cgroup
+func (c *cgroupStats) limited() bool {
+	return c.memLimit > 0 || c.cpuLimit > 0
+}
+
+// cpuPercent returns CPU usage relative to the cgroup quota between two samples
+func (c *cgroupStats) cpuPercent(prev *cgroupStats, elapsed time.Duration) (float64, bool) {
+	if prev == nil || c.cpuLimit <= 0 || elapsed <= 0 || c.cpuUsage < prev.cpuUsage {
+		return 0, false
+	}
+	used := float64(c.cpuUsage - prev.cpuUsage)
+	available := float64(elapsed.Microseconds()) * c.cpuLimit
+	return clampPercent(used / available * 100), true
+}
+
+// memPercent returns memory usage relative to the cgroup limit
+func (c *cgroupStats) memPercent() (float64, bool) {
+	if c.memLimit == 0 {
+		return 0, false
+	}
+	return clampPercent(float64(c.memUsage) / float64(c.memLimit) * 100), true
+}
+
+// readCgroup detects the cgroup version below root and reads its limits.
+// It returns nil when no cgroup filesystem is found.
+func readCgroup(root string) *cgroupStats {
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		return readCgroupV2(root)
+	}
+	if _, err := os.Stat(filepath.Join(root, "memory")); err == nil {
+		return readCgroupV1(root)
+	}
+	return nil
+}
+
+// readCgroupV2 reads the unified hierarchy
+func readCgroupV2(root string) *cgroupStats {
+	stats := &cgroupStats{version: 2}
+
+	if limit, ok := readCgroupUint(filepath.Join(root, "memory.max")); ok {
+		stats.memLimit = limit
+		usage, _ := readCgroupUint(filepath.Join(root, "memory.current"))
+		inactive := readCgroupStat(filepath.Join(root, "memory.stat"), "inactive_file")
+		stats.memUsage = saturatingSub(usage, inactive)
+		stats.memCache = readCgroupStat(filepath.Join(root, "memory.stat"), "file")
+	}
+
+	// cpu.max holds "$QUOTA $PERIOD" or "max $PERIOD"
+	if fields := strings.Fields(readCgroupString(filepath.Join(root, "cpu.max"))); len(fields) == 2 {
+		quota, errQuota := strconv.ParseFloat(fields[0], 64)
+		period, errPeriod := strconv.ParseFloat(fields[1], 64)
+		if errQuota == nil && errPeriod == nil && period > 0 {
+			stats.cpuLimit = quota / period
+		}
+	}
+	stats.cpuUsage = readCgroupStat(filepath.Join(root, "cpu.stat"), "usage_usec")
+
+	return stats
+}
+
+// readCgroupV1 reads the legacy per-controller hierarchies
+func readCgroupV1(root string) *cgroupStats {
+	stats := &cgroupStats{version: 1}
+
+	memDir := filepath.Join(root, "memory")
+	if limit, ok := readCgroupUint(filepath.Join(memDir, "memory.limit_in_bytes")); ok && limit < cgroupV1Unlimited {
+		stats.memLimit = limit
+		usage, _ := readCgroupUint(filepath.Join(memDir, "memory.usage_in_bytes"))
+		inactive := readCgroupStat(filepath.Join(memDir, "memory.stat"), "total_inactive_file")
+		stats.memUsage = saturatingSub(usage, inactive)
+		stats.memCache = readCgroupStat(filepath.Join(memDir, "memory.stat"), "total_cache")
+	}
+
+	cpuDir := firstExisting(filepath.Join(root, "cpu"), filepath.Join(root, "cpu,cpuacct"))
+	quota, errQuota := strconv.ParseFloat(readCgroupString(filepath.Join(cpuDir, "cpu.cfs_quota_us")), 64)
+	period, errPeriod := strconv.ParseFloat(readCgroupString(filepath.Join(cpuDir, "cpu.cfs_period_us")), 64)
+	if errQuota == nil && errPeriod == nil && quota > 0 && period > 0 {
+		stats.cpuLimit = quota / period
+	}
+
+	acctDir := firstExisting(filepath.Join(root, "cpuacct"), filepath.Join(root, "cpu,cpuacct"))
+	if usage, ok := readCgroupUint(filepath.Join(acctDir, "cpuacct.usage")); ok {
+		stats.cpuUsage = usage / nsPerUsec
+	}
+
+	return stats
+}
+
+// readCgroupString reads a trimmed cgroup file, returning "" if unavailable
+func readCgroupString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readCgroupUint reads a numeric cgroup file; "max" and missing files report false
+func readCgroupUint(path string) (uint64, bool) {
+	v, err := strconv.ParseUint(readCgroupString(path), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// readCgroupStat reads a single key from a flat keyed file such as memory.stat
+func readCgroupStat(path, key string) uint64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || k != key {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return 0
+}
+
+// firstExisting returns the first path that exists, or the first path
+func firstExisting(paths ...string) string {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return paths[0]
+}
+
+// saturatingSub returns a-b, or zero if b is larger
+func saturatingSub(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+// clampPercent limits a percentage to 0-100
+func clampPercent(p float64) float64 {
+	if p < 0 {
+		return 0
+	}
+	if p > 100 {
+		return 100
+	}
+	return p
+}
+
+// String describes the cgroup hierarchy version
+func (c *cgroupStats) String() string {
+	return fmt.Sprintf("cgroup v%d", c.version)
+}
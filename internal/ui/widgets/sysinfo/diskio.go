@@ -0,0 +1,97 @@
+package sysinfo
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+const (
+	// diskIOHistorySize is the number of samples kept per device for sparklines
+	diskIOHistorySize = 30
+	// maxDiskIODevices limits how many devices are rendered
+	maxDiskIODevices = 4
+	// defaultDiskIOThreshold is the utilization percentage above which a device is highlighted
+	defaultDiskIOThreshold = 80.0
+	envDiskIOThreshold     = "DASHBOARD_DISK_IO_THRESHOLD"
+)
+
+// ignoredDiskPrefixes lists virtual block devices that are not worth displaying
+var ignoredDiskPrefixes = []string{"loop", "ram", "zram"}
+
+// diskIOStat holds per-device throughput computed between two samples
+type diskIOStat struct {
+	name        string
+	readRate    float64 // bytes per second
+	writeRate   float64 // bytes per second
+	readIOPS    float64
+	writeIOPS   float64
+	utilization float64 // percentage of the interval the device was busy
+}
+
+// totalRate returns the combined read and write throughput
+func (s diskIOStat) totalRate() float64 {
+	return s.readRate + s.writeRate
+}
+
+// computeDiskIO derives rates from two IOCounters snapshots taken elapsed apart.
+// Devices missing from prev are skipped until a second sample is available.
+func computeDiskIO(prev, cur map[string]disk.IOCountersStat, elapsed time.Duration) []diskIOStat {
+	secs := elapsed.Seconds()
+	if secs <= 0 {
+		return nil
+	}
+
+	stats := make([]diskIOStat, 0, len(cur))
+	for name, c := range cur {
+		if isIgnoredDisk(name) {
+			continue
+		}
+		p, ok := prev[name]
+		if !ok {
+			continue
+		}
+
+		// IoTime is in milliseconds; elapsed may be shorter than one
+		util := float64(format.CounterDelta(p.IoTime, c.IoTime)) / (secs * 1000) * 100
+		if util > 100 {
+			util = 100
+		}
+
+		stats = append(stats, diskIOStat{
+			name:        name,
+			readRate:    float64(format.CounterDelta(p.ReadBytes, c.ReadBytes)) / secs,
+			writeRate:   float64(format.CounterDelta(p.WriteBytes, c.WriteBytes)) / secs,
+			readIOPS:    float64(format.CounterDelta(p.ReadCount, c.ReadCount)) / secs,
+			writeIOPS:   float64(format.CounterDelta(p.WriteCount, c.WriteCount)) / secs,
+			utilization: util,
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].name < stats[j].name
+	})
+	return stats
+}
+
+// isIgnoredDisk reports whether a device should be hidden from the I/O view
+func isIgnoredDisk(name string) bool {
+	for _, prefix := range ignoredDiskPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendHistory appends v to history, keeping at most diskIOHistorySize samples
+func appendHistory(history []float64, v float64) []float64 {
+	history = append(history, v)
+	if len(history) > diskIOHistorySize {
+		history = history[len(history)-diskIOHistorySize:]
+	}
+	return history
+}
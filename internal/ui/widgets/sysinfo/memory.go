@@ -0,0 +1,148 @@
+package sysinfo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// procPressureDir is where Linux exposes pressure stall information (PSI)
+const procPressureDir = "/proc/pressure"
+
+// memoryDetail holds absolute memory and swap figures in bytes
+type memoryDetail struct {
+	total     uint64
+	used      uint64
+	available uint64
+	buffers   uint64
+	cached    uint64
+
+	swapTotal   uint64
+	swapUsed    uint64
+	swapPercent float64
+}
+
+// newMemoryDetail combines virtual memory and swap statistics.
+// Either argument may be nil when the corresponding call failed.
+func newMemoryDetail(vm *mem.VirtualMemoryStat, swap *mem.SwapMemoryStat) memoryDetail {
+	var d memoryDetail
+	if vm != nil {
+		d.total = vm.Total
+		d.used = vm.Used
+		d.available = vm.Available
+		d.buffers = vm.Buffers
+		d.cached = vm.Cached
+	}
+	if swap != nil {
+		d.swapTotal = swap.Total
+		d.swapUsed = swap.Used
+		d.swapPercent = swap.UsedPercent
+	}
+	return d
+}
+
+// pressureLine holds the averages of one "some" or "full" PSI line
+type pressureLine struct {
+	avg10  float64
+	avg60  float64
+	avg300 float64
+}
+
+// pressure holds the PSI values of a single resource
+type pressure struct {
+	some pressureLine
+	full pressureLine
+	// hasFull is false for resources that only report "some" (e.g. cpu on older kernels)
+	hasFull bool
+}
+
+// pressureStats holds PSI values for the resources shown in the memory detail view
+type pressureStats struct {
+	memory pressure
+	cpu    pressure
+}
+
+// readPressureStats reads memory and cpu PSI from dir. It returns nil when
+// PSI is unavailable, such as on non-Linux systems or older kernels.
+func readPressureStats(dir string) *pressureStats {
+	memPSI, err := readPressure(filepath.Join(dir, "memory"))
+	if err != nil {
+		return nil
+	}
+	cpuPSI, err := readPressure(filepath.Join(dir, "cpu"))
+	if err != nil {
+		return nil
+	}
+	return &pressureStats{memory: memPSI, cpu: cpuPSI}
+}
+
+// readPressure reads a single PSI file
+func readPressure(path string) (pressure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return pressure{}, fmt.Errorf("failed to open pressure file: %w", err)
+	}
+	defer f.Close()
+	return parsePressure(f)
+}
+
+// parsePressure parses the PSI format:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressure(r io.Reader) (pressure, error) {
+	var p pressure
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var line pressureLine
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return pressure{}, fmt.Errorf("malformed pressure field %q", field)
+			}
+			if key == "total" {
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return pressure{}, fmt.Errorf("malformed pressure value %q: %w", field, err)
+			}
+			switch key {
+			case "avg10":
+				line.avg10 = v
+			case "avg60":
+				line.avg60 = v
+			case "avg300":
+				line.avg300 = v
+			}
+		}
+
+		switch fields[0] {
+		case "some":
+			p.some = line
+		case "full":
+			p.full = line
+			p.hasFull = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return pressure{}, fmt.Errorf("failed to read pressure: %w", err)
+	}
+	return p, nil
+}
+
+// String formats the averages as "10s/60s/300s" percentages
+func (l pressureLine) String() string {
+	return fmt.Sprintf("%.2f/%.2f/%.2f", l.avg10, l.avg60, l.avg300)
+}
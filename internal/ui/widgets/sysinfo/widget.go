@@ -3,12 +3,17 @@ package sysinfo
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/alerts"
+	"github.com/jonesrussell/dashboard/internal/logger"
 	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
 	"github.com/jonesrussell/dashboard/internal/ui/styles"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
@@ -21,11 +26,85 @@ type Widget struct {
 	cpuUsage    float64
 	memoryUsage float64
 	diskUsage   float64
+
+	// Memory detail state
+	memDetail        memoryDetail
+	pressure         *pressureStats
+	showMemoryDetail bool
+
+	// Disk I/O state
+	diskIOThreshold float64
+	diskIOPrev      map[string]disk.IOCountersStat
+	diskIOPrevAt    time.Time
+	diskIO          []diskIOStat
+	diskIOHistory   map[string][]float64
+
+	// Container scope state
+	cgroupRoot string
+	cgroup     *cgroupStats
+	cgroupAt   time.Time
+	cpuScoped  bool
+	memScoped  bool
+
+	// Alerting state
+	alerts     *alerts.Evaluator
+	notifier   alerts.Notifier
+	alertPaths []string
+	logger     logger.Logger
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithDiskIOThreshold sets the device utilization percentage above which
+// a device is highlighted in the disk I/O section
+func WithDiskIOThreshold(percent float64) Option {
+	return func(w *Widget) {
+		w.diskIOThreshold = percent
+	}
+}
+
+// WithLogger sets the logger used for alert transitions
+func WithLogger(log logger.Logger) Option {
+	return func(w *Widget) {
+		w.logger = log
+	}
 }
 
-// New creates a new system information widget
-func New() *Widget {
-	return &Widget{}
+// WithAlerts enables threshold alerting with the given rules and notifications
+func WithAlerts(cfg alerts.Config) Option {
+	return func(w *Widget) {
+		w.alerts = alerts.NewEvaluator(cfg.Rules)
+		w.notifier = alerts.NewNotifier(cfg)
+		w.alertPaths = w.alerts.DiskPaths()
+	}
+}
+
+// WithCgroupRoot sets where the cgroup filesystem is read from
+func WithCgroupRoot(root string) Option {
+	return func(w *Widget) {
+		w.cgroupRoot = root
+	}
+}
+
+// New creates a new system information widget. The disk I/O threshold
+// defaults to DASHBOARD_DISK_IO_THRESHOLD when set to a percentage.
+func New(opts ...Option) *Widget {
+	w := &Widget{
+		diskIOThreshold: defaultDiskIOThreshold,
+		diskIOHistory:   make(map[string][]float64),
+		cgroupRoot:      defaultCgroupRoot,
+	}
+	threshold := strings.TrimSuffix(strings.TrimSpace(os.Getenv(envDiskIOThreshold)), "%")
+	if v, err := strconv.ParseFloat(threshold, 64); err == nil && v > 0 && v <= 100 {
+		WithDiskIOThreshold(v)(w)
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
 }
 
 // Init implements components.Widget
@@ -40,11 +119,22 @@ func (w *Widget) Init() tea.Cmd {
 // Update implements components.Widget
 func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		if msg.String() == "m" {
+			w.showMemoryDetail = !w.showMemoryDetail
+		}
 	case systemInfoMsg:
+		msg = w.applyCgroup(msg)
 		w.cpuUsage = msg.cpu
 		w.memoryUsage = msg.memory
 		w.diskUsage = msg.disk
-		return w, w.tick()
+		w.memDetail = msg.memDetail
+		w.pressure = msg.pressure
+		w.updateDiskIO(msg.diskIO, msg.sampledAt)
+		return w, tea.Batch(append(w.evaluateAlerts(msg), w.tick())...)
 	case updateSystemInfoMsg:
 		return w, w.updateSystemInfo
 	}
@@ -68,12 +158,13 @@ func (w *Widget) View() string {
 	}
 
 	// Format system info with bars
-	cpuBar := createUsageBar(w.cpuUsage, barWidth)
-	memBar := createUsageBar(w.memoryUsage, barWidth)
-	diskBar := createUsageBar(w.diskUsage, barWidth)
+	cpuBar := components.UsageBar(w.cpuUsage, barWidth, w.barColor(alerts.MetricCPU))
+	memBar := components.UsageBar(w.memoryUsage, barWidth, w.barColor(alerts.MetricMemory))
+	diskBar := components.UsageBar(w.diskUsage, barWidth, w.barColor(alerts.DiskMetric("/")))
 
 	// CPU
 	b.WriteString(styles.Title.Render("CPU"))
+	b.WriteString(" " + w.cpuScopeLabel())
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("%.1f%% ", w.cpuUsage))
 	b.WriteString(cpuBar)
@@ -81,9 +172,11 @@ func (w *Widget) View() string {
 
 	// Memory
 	b.WriteString(styles.Title.Render("Memory"))
+	b.WriteString(" " + w.memScopeLabel())
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("%.1f%% ", w.memoryUsage))
 	b.WriteString(memBar)
+	w.renderMemory(&b, barWidth)
 	b.WriteString("\n\n")
 
 	// Disk
@@ -92,6 +185,20 @@ func (w *Widget) View() string {
 	b.WriteString(fmt.Sprintf("%.1f%% ", w.diskUsage))
 	b.WriteString(diskBar)
 
+	// Disk I/O
+	if len(w.diskIO) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(styles.Title.Render("Disk I/O"))
+		w.renderDiskIO(&b, barWidth)
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n\n")
+		helpStyle := lipgloss.NewStyle().Foreground(styles.Subtle)
+		b.WriteString(helpStyle.Render("m: memory details"))
+	}
+
 	return w.GetStyle().Width(width).Height(height).Render(b.String())
 }
 
@@ -111,27 +218,218 @@ func (w *Widget) Blur() {
 	w.Focused = false
 }
 
-// createUsageBar creates a progress bar for the given percentage
-func createUsageBar(percent float64, width int) string {
-	// Ensure valid percentage
-	if percent < 0 {
-		percent = 0
-	} else if percent > 100 {
-		percent = 100
+// applyCgroup rescales CPU and memory usage to the cgroup limits when the
+// dashboard runs in a constrained container. The memory detail is replaced
+// with cgroup figures so it doesn't mix container and host values; cgroups
+// don't account buffers separately, so that figure is cleared.
+func (w *Widget) applyCgroup(msg systemInfoMsg) systemInfoMsg {
+	w.cpuScoped = false
+	w.memScoped = false
+
+	cg := msg.cgroup
+	if cg == nil || !cg.limited() {
+		w.cgroup = nil
+		return msg
+	}
+
+	if percent, ok := cg.memPercent(); ok {
+		msg.memory = percent
+		msg.memDetail.used = cg.memUsage
+		msg.memDetail.total = cg.memLimit
+		msg.memDetail.available = saturatingSub(cg.memLimit, cg.memUsage)
+		msg.memDetail.buffers = 0
+		msg.memDetail.cached = cg.memCache
+		w.memScoped = true
+	}
+	if percent, ok := cg.cpuPercent(w.cgroup, msg.sampledAt.Sub(w.cgroupAt)); ok {
+		msg.cpu = percent
+		w.cpuScoped = true
+	}
+
+	w.cgroup = cg
+	w.cgroupAt = msg.sampledAt
+	return msg
+}
+
+// cpuScopeLabel describes whether CPU usage is host-wide or container-relative
+func (w *Widget) cpuScopeLabel() string {
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	if !w.cpuScoped {
+		return subtle.Render("(host)")
+	}
+	return subtle.Render(fmt.Sprintf("(container, %.1f cores, %s)", w.cgroup.cpuLimit, w.cgroup))
+}
+
+// memScopeLabel describes whether memory usage is host-wide or container-relative
+func (w *Widget) memScopeLabel() string {
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	if !w.memScoped {
+		return subtle.Render("(host)")
+	}
+	return subtle.Render(fmt.Sprintf("(container, %s)", w.cgroup))
+}
+
+// renderMemory writes absolute memory figures, swap usage and, in detail
+// mode, the buffers/cache breakdown and pressure stall information
+func (w *Widget) renderMemory(b *strings.Builder, barWidth int) {
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	d := w.memDetail
+
+	b.WriteString("\n")
+	b.WriteString(subtle.Render(fmt.Sprintf("%s / %s", format.Bytes(d.used), format.Bytes(d.total))))
+
+	if w.showMemoryDetail {
+		detail := fmt.Sprintf("avail %s  buffers %s  cached %s",
+			format.Bytes(d.available), format.Bytes(d.buffers), format.Bytes(d.cached))
+		if w.memScoped {
+			detail = fmt.Sprintf("avail %s  cached %s", format.Bytes(d.available), format.Bytes(d.cached))
+		}
+		b.WriteString("\n")
+		b.WriteString(subtle.Render(detail))
+	}
+
+	if d.swapTotal > 0 {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Swap %.1f%% ", d.swapPercent))
+		b.WriteString(components.UsageBar(d.swapPercent, barWidth, w.barColor(alerts.MetricSwap)))
+		b.WriteString("\n")
+		b.WriteString(subtle.Render(fmt.Sprintf("%s / %s", format.Bytes(d.swapUsed), format.Bytes(d.swapTotal))))
+	}
+
+	if w.showMemoryDetail && w.pressure != nil {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("PSI avg10/60/300"))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("mem some %s", w.pressure.memory.some))
+		if w.pressure.memory.hasFull {
+			b.WriteString(fmt.Sprintf("  full %s", w.pressure.memory.full))
+		}
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("cpu some %s", w.pressure.cpu.some))
+	}
+}
+
+// updateDiskIO computes per-device rates from a new IOCounters sample
+func (w *Widget) updateDiskIO(counters map[string]disk.IOCountersStat, sampledAt time.Time) {
+	if counters == nil {
+		return
+	}
+
+	if w.diskIOPrev != nil {
+		w.diskIO = computeDiskIO(w.diskIOPrev, counters, sampledAt.Sub(w.diskIOPrevAt))
+		for _, stat := range w.diskIO {
+			w.diskIOHistory[stat.name] = appendHistory(w.diskIOHistory[stat.name], stat.totalRate())
+		}
+	}
+
+	w.diskIOPrev = counters
+	w.diskIOPrevAt = sampledAt
+}
+
+// renderDiskIO writes one entry per block device with rates, IOPS and a sparkline
+func (w *Widget) renderDiskIO(b *strings.Builder, sparkWidth int) {
+	hot := lipgloss.NewStyle().Foreground(styles.Secondary).Bold(true)
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	for i, stat := range w.diskIO {
+		if i >= maxDiskIODevices {
+			break
+		}
+
+		header := fmt.Sprintf("%s %.1f%% ", stat.name, stat.utilization)
+		if stat.utilization >= w.diskIOThreshold {
+			header = hot.Render(header)
+		}
+
+		b.WriteString("\n")
+		b.WriteString(header)
+		b.WriteString(components.Sparkline(w.diskIOHistory[stat.name], sparkWidth))
+		b.WriteString("\n")
+		b.WriteString(subtle.Render(fmt.Sprintf("  R %s (%.0f IOPS)  W %s (%.0f IOPS)",
+			format.Rate(stat.readRate), stat.readIOPS,
+			format.Rate(stat.writeRate), stat.writeIOPS,
+		)))
+	}
+}
+
+// barColor returns the bar color for a metric based on its alert level
+func (w *Widget) barColor(metric string) lipgloss.Color {
+	if w.alerts == nil {
+		return styles.Primary
+	}
+	switch w.alerts.Level(metric) {
+	case alerts.SeverityCritical:
+		return styles.Critical
+	case alerts.SeverityWarning:
+		return styles.Warning
+	}
+	return styles.Primary
+}
+
+// evaluateAlerts applies the alert rules to a sample, logs transitions and
+// returns commands for notifications and the dashboard status update
+func (w *Widget) evaluateAlerts(msg systemInfoMsg) []tea.Cmd {
+	if w.alerts == nil {
+		return nil
 	}
 
-	// Calculate filled and empty portions
-	filled := int(float64(width) * percent / 100)
-	if filled > width {
-		filled = width
+	sample := alerts.Sample{
+		alerts.MetricCPU:       msg.cpu,
+		alerts.MetricMemory:    msg.memory,
+		alerts.DiskMetric("/"): msg.disk,
+	}
+	if msg.memDetail.swapTotal > 0 {
+		sample[alerts.MetricSwap] = msg.memDetail.swapPercent
+	}
+	for path, percent := range msg.diskPaths {
+		sample[alerts.DiskMetric(path)] = percent
+	}
+
+	transitions := w.alerts.Evaluate(sample, msg.sampledAt)
+	if len(transitions) == 0 {
+		return nil
 	}
-	empty := width - filled
 
-	// Create the bar with colors
-	bar := lipgloss.NewStyle().Foreground(styles.Primary).Render(strings.Repeat("█", filled)) +
-		lipgloss.NewStyle().Foreground(styles.Subtle).Render(strings.Repeat("░", empty))
+	cmds := make([]tea.Cmd, 0, len(transitions)+1)
+	for _, t := range transitions {
+		w.logTransition(t)
+		cmds = append(cmds, w.notify(t))
+	}
+	status := w.alerts.Status()
+	cmds = append(cmds, func() tea.Msg { return status })
+	return cmds
+}
+
+// logTransition records an alert state change
+func (w *Widget) logTransition(t alerts.Transition) {
+	if w.logger == nil {
+		return
+	}
+	fields := []logger.Field{
+		logger.NewField("rule", t.Rule.Expr),
+		logger.NewField("severity", t.Rule.Severity.String()),
+		logger.NewField("value", t.Value),
+	}
+	if t.Firing {
+		w.logger.Warn("Alert firing", fields...)
+	} else {
+		w.logger.Info("Alert resolved", fields...)
+	}
+}
 
-	return bar
+// notify returns a command delivering a transition to the notifier
+func (w *Widget) notify(t alerts.Transition) tea.Cmd {
+	notifier := w.notifier
+	log := w.logger
+	return func() tea.Msg {
+		if err := notifier.Notify(t); err != nil && log != nil {
+			log.Error("Failed to deliver alert",
+				logger.NewField("rule", t.Rule.Expr),
+				logger.NewField("error", err),
+			)
+		}
+		return nil
+	}
 }
 
 // systemInfoMsg carries system information updates
@@ -139,6 +437,14 @@ type systemInfoMsg struct {
 	cpu    float64
 	memory float64
 	disk   float64
+
+	memDetail memoryDetail
+	pressure  *pressureStats
+	diskPaths map[string]float64
+	cgroup    *cgroupStats
+
+	diskIO    map[string]disk.IOCountersStat
+	sampledAt time.Time
 }
 
 // tick returns a command that waits for the update interval
@@ -166,6 +472,12 @@ func (w *Widget) updateSystemInfo() tea.Msg {
 		memPercent = memInfo.UsedPercent
 	}
 
+	// Get swap usage; a failure leaves swap out of the view
+	swapInfo, err := mem.SwapMemory()
+	if err != nil {
+		swapInfo = nil
+	}
+
 	// Get disk usage
 	diskInfo, err := disk.Usage("/")
 	diskPercent := 0.0
@@ -173,9 +485,32 @@ func (w *Widget) updateSystemInfo() tea.Msg {
 		diskPercent = diskInfo.UsedPercent
 	}
 
+	// Get usage of additional mount points referenced by alert rules
+	var diskPaths map[string]float64
+	for _, path := range w.alertPaths {
+		if usage, err := disk.Usage(path); err == nil {
+			if diskPaths == nil {
+				diskPaths = make(map[string]float64)
+			}
+			diskPaths[path] = usage.UsedPercent
+		}
+	}
+
+	// Get disk I/O counters; rates are derived from consecutive samples
+	ioCounters, err := disk.IOCounters()
+	if err != nil {
+		ioCounters = nil
+	}
+
 	return systemInfoMsg{
-		cpu:    cpuPercent[0],
-		memory: memPercent,
-		disk:   diskPercent,
+		cpu:       cpuPercent[0],
+		memory:    memPercent,
+		disk:      diskPercent,
+		memDetail: newMemoryDetail(memInfo, swapInfo),
+		pressure:  readPressureStats(procPressureDir),
+		diskPaths: diskPaths,
+		cgroup:    readCgroup(w.cgroupRoot),
+		diskIO:    ioCounters,
+		sampledAt: time.Now(),
 	}
 }
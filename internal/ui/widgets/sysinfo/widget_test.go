@@ -0,0 +1,309 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jonesrussell/dashboard/internal/alerts"
+	"github.com/jonesrussell/dashboard/internal/testutil/testlogger"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskIO(t *testing.T) {
+	prev := map[string]disk.IOCountersStat{
+		"sda":   {Name: "sda", ReadBytes: 1000, WriteBytes: 2000, ReadCount: 10, WriteCount: 20, IoTime: 100},
+		"loop0": {Name: "loop0", ReadBytes: 0},
+	}
+	cur := map[string]disk.IOCountersStat{
+		"sda":   {Name: "sda", ReadBytes: 5000, WriteBytes: 4000, ReadCount: 30, WriteCount: 24, IoTime: 1100},
+		"loop0": {Name: "loop0", ReadBytes: 100},
+		"sdb":   {Name: "sdb", ReadBytes: 100},
+	}
+
+	t.Run("computes rates from deltas", func(t *testing.T) {
+		stats := computeDiskIO(prev, cur, 2*time.Second)
+		require.Len(t, stats, 1, "loop devices and new devices are skipped")
+
+		sda := stats[0]
+		assert.Equal(t, "sda", sda.name)
+		assert.InDelta(t, 2000, sda.readRate, 0.01)
+		assert.InDelta(t, 1000, sda.writeRate, 0.01)
+		assert.InDelta(t, 10, sda.readIOPS, 0.01)
+		assert.InDelta(t, 2, sda.writeIOPS, 0.01)
+		assert.InDelta(t, 50, sda.utilization, 0.01)
+	})
+
+	t.Run("counter reset yields zero", func(t *testing.T) {
+		stats := computeDiskIO(cur, prev, time.Second)
+		require.Len(t, stats, 1)
+		assert.Zero(t, stats[0].readRate)
+	})
+
+	t.Run("zero interval", func(t *testing.T) {
+		assert.Nil(t, computeDiskIO(prev, cur, 0))
+	})
+
+	t.Run("sub-millisecond interval", func(t *testing.T) {
+		stats := computeDiskIO(prev, cur, 500*time.Microsecond)
+		require.Len(t, stats, 1)
+		assert.Equal(t, 100.0, stats[0].utilization)
+	})
+
+	t.Run("env threshold", func(t *testing.T) {
+		t.Setenv(envDiskIOThreshold, "60%")
+		assert.Equal(t, 60.0, New().diskIOThreshold)
+		assert.Equal(t, 40.0, New(WithDiskIOThreshold(40)).diskIOThreshold, "options win")
+
+		t.Setenv(envDiskIOThreshold, "busy")
+		assert.Equal(t, defaultDiskIOThreshold, New().diskIOThreshold)
+	})
+
+	t.Run("widget highlights busy devices", func(t *testing.T) {
+		w := New(WithDiskIOThreshold(40))
+		w.SetSize(80, 30)
+		now := time.Now()
+
+		w.updateDiskIO(prev, now)
+		assert.Empty(t, w.diskIO, "first sample has no rates")
+
+		w.updateDiskIO(cur, now.Add(2*time.Second))
+		require.Len(t, w.diskIO, 1)
+		assert.Len(t, w.diskIOHistory["sda"], 1)
+
+		view := w.View()
+		assert.Contains(t, view, "Disk I/O")
+		assert.Contains(t, view, "sda 50.0%")
+		assert.Contains(t, view, "2.0 KiB/s")
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		var history []float64
+		for i := 0; i < diskIOHistorySize+5; i++ {
+			history = appendHistory(history, float64(i))
+		}
+		assert.Len(t, history, diskIOHistorySize)
+		assert.Equal(t, float64(diskIOHistorySize+4), history[len(history)-1])
+	})
+}
+
+func TestPressure(t *testing.T) {
+	t.Run("parses some and full", func(t *testing.T) {
+		p, err := parsePressure(strings.NewReader(
+			"some avg10=1.50 avg60=0.75 avg300=0.10 total=12345\n" +
+				"full avg10=0.20 avg60=0.00 avg300=0.00 total=42\n"))
+		require.NoError(t, err)
+		assert.InDelta(t, 1.5, p.some.avg10, 0.001)
+		assert.InDelta(t, 0.75, p.some.avg60, 0.001)
+		assert.True(t, p.hasFull)
+		assert.Equal(t, "0.20/0.00/0.00", p.full.String())
+	})
+
+	t.Run("some only", func(t *testing.T) {
+		p, err := parsePressure(strings.NewReader("some avg10=3.00 avg60=2.00 avg300=1.00 total=1\n"))
+		require.NoError(t, err)
+		assert.False(t, p.hasFull)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := parsePressure(strings.NewReader("some avg10=abc\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("reads directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "memory"),
+			[]byte("some avg10=4.00 avg60=0.00 avg300=0.00 total=0\n"), 0o644))
+		assert.Nil(t, readPressureStats(dir), "cpu file missing")
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cpu"),
+			[]byte("some avg10=9.00 avg60=0.00 avg300=0.00 total=0\n"), 0o644))
+		stats := readPressureStats(dir)
+		require.NotNil(t, stats)
+		assert.InDelta(t, 4.0, stats.memory.some.avg10, 0.001)
+		assert.InDelta(t, 9.0, stats.cpu.some.avg10, 0.001)
+	})
+}
+
+func TestMemoryDetail(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	w := New()
+	w.SetSize(80, 40)
+	w.Focus()
+	w.Update(systemInfoMsg{
+		memory: 50,
+		memDetail: newMemoryDetail(
+			&mem.VirtualMemoryStat{Total: 16 * gib, Used: 8 * gib, Available: 7 * gib, Buffers: gib, Cached: 2 * gib},
+			&mem.SwapMemoryStat{Total: 4 * gib, Used: gib, UsedPercent: 25},
+		),
+		pressure:  &pressureStats{memory: pressure{some: pressureLine{avg10: 1.25}}},
+		sampledAt: time.Now(),
+	})
+
+	view := w.View()
+	assert.Contains(t, view, "8.0 GiB / 16.0 GiB")
+	assert.Contains(t, view, "Swap 25.0%")
+	assert.Contains(t, view, "1.0 GiB / 4.0 GiB")
+	assert.NotContains(t, view, "cached")
+
+	w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	view = w.View()
+	assert.Contains(t, view, "avail 7.0 GiB  buffers 1.0 GiB  cached 2.0 GiB")
+	assert.Contains(t, view, "mem some 1.25/0.00/0.00")
+}
+
+func TestAlerts(t *testing.T) {
+	log, logPath := testlogger.NewTestLogger(t, "sysinfo-alerts")
+	rules, err := alerts.ParseRules("cpu > 90%; critical: memory > 80%")
+	require.NoError(t, err)
+
+	w := New(WithLogger(log), WithAlerts(alerts.Config{Rules: rules}))
+	w.SetSize(80, 40)
+	assert.Equal(t, styles.Primary, w.barColor(alerts.MetricCPU))
+
+	cmds := w.evaluateAlerts(systemInfoMsg{cpu: 95, memory: 85, sampledAt: time.Now()})
+	assert.Len(t, cmds, 3, "two notifications and a status update")
+	assert.Equal(t, styles.Warning, w.barColor(alerts.MetricCPU))
+	assert.Equal(t, styles.Critical, w.barColor(alerts.MetricMemory))
+
+	var status *alerts.StatusMsg
+	for _, c := range cmds {
+		if msg, ok := c().(alerts.StatusMsg); ok {
+			status = &msg
+		}
+	}
+	require.NotNil(t, status)
+	assert.Equal(t, alerts.StatusMsg{Active: 2, Level: alerts.SeverityCritical}, *status)
+	assert.Empty(t, w.evaluateAlerts(systemInfoMsg{cpu: 95, memory: 85, sampledAt: time.Now()}),
+		"no transitions while alerts stay active")
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Alert firing")
+	assert.Contains(t, string(data), `"severity":"critical"`)
+}
+
+// writeFiles creates files with the given contents below root
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestCgroup(t *testing.T) {
+	const mib = 1024 * 1024
+
+	t.Run("v2 limits", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"cgroup.controllers": "cpu memory\n",
+			"memory.max":         "536870912\n",
+			"memory.current":     "300000000\n",
+			"memory.stat":        "anon 100\nfile 52428800\ninactive_file 31564544\n",
+			"cpu.max":            "200000 100000\n",
+			"cpu.stat":           "usage_usec 5000000\nuser_usec 4000000\n",
+		})
+
+		cg := readCgroup(root)
+		require.NotNil(t, cg)
+		assert.Equal(t, 2, cg.version)
+		assert.Equal(t, uint64(512*mib), cg.memLimit)
+		assert.Equal(t, uint64(300000000-31564544), cg.memUsage)
+		assert.Equal(t, uint64(50*mib), cg.memCache)
+		assert.InDelta(t, 2.0, cg.cpuLimit, 0.001)
+		assert.Equal(t, uint64(5000000), cg.cpuUsage)
+		assert.True(t, cg.limited())
+	})
+
+	t.Run("v2 unlimited", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"cgroup.controllers": "cpu memory\n",
+			"memory.max":         "max\n",
+			"cpu.max":            "max 100000\n",
+		})
+
+		cg := readCgroup(root)
+		require.NotNil(t, cg)
+		assert.False(t, cg.limited())
+	})
+
+	t.Run("v1 limits", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"memory/memory.limit_in_bytes":  "1073741824\n",
+			"memory/memory.usage_in_bytes":  "536870912\n",
+			"memory/memory.stat":            "total_cache 1048576\ntotal_inactive_file 0\n",
+			"cpu,cpuacct/cpu.cfs_quota_us":  "50000\n",
+			"cpu,cpuacct/cpu.cfs_period_us": "100000\n",
+			"cpu,cpuacct/cpuacct.usage":     "3000000000\n",
+		})
+
+		cg := readCgroup(root)
+		require.NotNil(t, cg)
+		assert.Equal(t, 1, cg.version)
+		percent, ok := cg.memPercent()
+		assert.True(t, ok)
+		assert.InDelta(t, 50, percent, 0.001)
+		assert.Equal(t, uint64(mib), cg.memCache)
+		assert.InDelta(t, 0.5, cg.cpuLimit, 0.001)
+		assert.Equal(t, uint64(3000000), cg.cpuUsage)
+	})
+
+	t.Run("v1 unlimited", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"memory/memory.limit_in_bytes": "9223372036854771712\n",
+			"cpu/cpu.cfs_quota_us":         "-1\n",
+			"cpu/cpu.cfs_period_us":        "100000\n",
+		})
+
+		cg := readCgroup(root)
+		require.NotNil(t, cg)
+		assert.False(t, cg.limited())
+	})
+
+	t.Run("no cgroup filesystem", func(t *testing.T) {
+		assert.Nil(t, readCgroup(t.TempDir()))
+	})
+
+	t.Run("widget reports container scope", func(t *testing.T) {
+		w := New(WithCgroupRoot(t.TempDir()))
+		w.SetSize(100, 40)
+		w.Focus()
+		now := time.Now()
+
+		first := &cgroupStats{version: 2, memLimit: 512 * mib, memUsage: 128 * mib, memCache: 64 * mib, cpuLimit: 2, cpuUsage: 1000000}
+		w.Update(systemInfoMsg{cpu: 10, memory: 90, cgroup: first, sampledAt: now})
+		assert.InDelta(t, 25, w.memoryUsage, 0.001)
+		assert.InDelta(t, 10, w.cpuUsage, 0.001, "host CPU until a second sample arrives")
+		assert.Contains(t, w.View(), "(container, cgroup v2)")
+		assert.Contains(t, w.View(), "128.0 MiB / 512.0 MiB")
+
+		// Host-only figures are not mixed into the container detail
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+		assert.Contains(t, w.View(), "avail 384.0 MiB  cached 64.0 MiB")
+		assert.NotContains(t, w.View(), "buffers")
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+
+		// One core busy for two seconds out of a two-core quota
+		second := &cgroupStats{version: 2, memLimit: 512 * mib, memUsage: 128 * mib, cpuLimit: 2, cpuUsage: 3000000}
+		w.Update(systemInfoMsg{cpu: 10, memory: 90, cgroup: second, sampledAt: now.Add(2 * time.Second)})
+		assert.InDelta(t, 50, w.cpuUsage, 0.001)
+		assert.Contains(t, w.View(), "(container, 2.0 cores, cgroup v2)")
+
+		w.Update(systemInfoMsg{cpu: 10, memory: 90, sampledAt: now.Add(4 * time.Second)})
+		assert.InDelta(t, 90, w.memoryUsage, 0.001)
+		assert.NotContains(t, w.View(), "container")
+	})
+}
@@ -0,0 +1,153 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// showTimeLayout is the timestamp format printed by `systemctl show`
+const showTimeLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// Command deadlines; a restart waits for the unit to stop, which systemd
+// allows 90 seconds by default
+const (
+	showTimeout    = 10 * time.Second
+	restartTimeout = 2 * time.Minute
+)
+
+// showProperties are the unit properties requested from systemctl
+var showProperties = []string{"Id", "Description", "LoadState", "ActiveState", "SubState", "StateChangeTimestamp"}
+
+// Unit is the state of a single systemd unit
+type Unit struct {
+	Name        string
+	Description string
+	LoadState   string
+	ActiveState string
+	SubState    string
+	// Since is when the unit last changed state; zero if unknown
+	Since time.Time
+}
+
+// Failed reports whether the unit is in the failed state
+func (u Unit) Failed() bool {
+	return u.ActiveState == "failed"
+}
+
+// Backend queries and controls systemd units. It is an interface so the
+// widget can be tested without systemd.
+type Backend interface {
+	Units(names []string) ([]Unit, error)
+	Restart(name string) error
+}
+
+// systemctlBackend implements Backend by running systemctl
+type systemctlBackend struct {
+	user bool
+}
+
+// NewSystemctlBackend creates a Backend that shells out to systemctl. When
+// user is true it manages the calling user's service manager.
+func NewSystemctlBackend(user bool) Backend {
+	return systemctlBackend{user: user}
+}
+
+// Units implements Backend
+func (b systemctlBackend) Units(names []string) ([]Unit, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	args := append(b.baseArgs(), "show", "--property="+strings.Join(showProperties, ","), "--")
+	args = append(args, names...)
+	ctx, cancel := context.WithTimeout(context.Background(), showTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "systemctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("systemctl show failed: %w", commandError(ctx, err))
+	}
+	return parseShow(bytes.NewReader(out))
+}
+
+// Restart implements Backend. Polkit must not prompt for a password on the
+// dashboard's terminal, so units needing authorization fail instead.
+func (b systemctlBackend) Restart(name string) error {
+	args := append(b.baseArgs(), "--no-ask-password", "restart", "--", name)
+	ctx, cancel := context.WithTimeout(context.Background(), restartTimeout)
+	defer cancel()
+	if _, err := exec.CommandContext(ctx, "systemctl", args...).Output(); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", name, commandError(ctx, err))
+	}
+	return nil
+}
+
+func (b systemctlBackend) baseArgs() []string {
+	if b.user {
+		return []string{"--user"}
+	}
+	return nil
+}
+
+// commandError adds the stderr of a failed command to its error, or that
+// it was killed at its deadline
+func commandError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", err, ctx.Err())
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
+
+// parseShow parses `systemctl show` output: KEY=VALUE lines with a blank
+// line between units, in the order the units were requested
+func parseShow(r io.Reader) ([]Unit, error) {
+	var units []Unit
+	var cur *Unit
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			cur = nil
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if cur == nil {
+			units = append(units, Unit{})
+			cur = &units[len(units)-1]
+		}
+
+		switch key {
+		case "Id":
+			cur.Name = value
+		case "Description":
+			cur.Description = value
+		case "LoadState":
+			cur.LoadState = value
+		case "ActiveState":
+			cur.ActiveState = value
+		case "SubState":
+			cur.SubState = value
+		case "StateChangeTimestamp":
+			if t, err := time.Parse(showTimeLayout, value); err == nil {
+				cur.Since = t
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse systemctl output: %w", err)
+	}
+	return units, nil
+}
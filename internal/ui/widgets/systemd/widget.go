@@ -0,0 +1,317 @@
+// Package systemd provides a widget for monitoring and restarting systemd units
+package systemd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/logger"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/format"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	defaultInterval = 5 * time.Second
+	envUnits        = "DASHBOARD_SYSTEMD_UNITS"
+	envUser         = "DASHBOARD_SYSTEMD_USER"
+)
+
+// Widget represents the systemd units widget
+type Widget struct {
+	components.BaseWidget
+	backend  Backend
+	logger   logger.Logger
+	interval time.Duration
+	names    []string
+
+	units     []Unit
+	selected  int
+	sampledAt time.Time
+
+	confirm   string
+	status    string
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithBackend sets the systemd backend, mainly for tests
+func WithBackend(backend Backend) Option {
+	return func(w *Widget) {
+		w.backend = backend
+	}
+}
+
+// WithUnits sets the units to monitor
+func WithUnits(names ...string) Option {
+	return func(w *Widget) {
+		w.names = names
+	}
+}
+
+// WithInterval sets the refresh interval
+func WithInterval(interval time.Duration) Option {
+	return func(w *Widget) {
+		w.interval = interval
+	}
+}
+
+// New creates a new systemd widget. Units default to the comma-separated
+// DASHBOARD_SYSTEMD_UNITS; DASHBOARD_SYSTEMD_USER=true selects the user manager.
+func New(log logger.Logger, opts ...Option) *Widget {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	user, _ := strconv.ParseBool(os.Getenv(envUser))
+	w := &Widget{
+		backend:  NewSystemctlBackend(user),
+		logger:   log,
+		interval: defaultInterval,
+		names:    format.SplitList(os.Getenv(envUnits)),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	return w.fetchUnits
+}
+
+// CapturingInput implements components.InputCapturer
+func (w *Widget) CapturingInput() bool {
+	return w.confirm != ""
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		return w, w.handleKey(msg)
+	case unitsMsg:
+		w.lastError = msg.err
+		if msg.err == nil {
+			w.units = msg.units
+			w.sampledAt = msg.sampledAt
+			w.selected = min(w.selected, max(len(w.units)-1, 0))
+		}
+		if msg.manual {
+			// The interval refresh is already scheduled
+			return w, nil
+		}
+		return w, w.tick()
+	case refreshMsg:
+		return w, w.fetchUnits
+	case restartResultMsg:
+		if msg.err != nil {
+			w.logger.Error("Failed to restart unit",
+				logger.NewField("unit", msg.name),
+				logger.NewField("error", msg.err),
+			)
+			w.lastError = msg.err
+			return w, nil
+		}
+		w.logger.Info("Restarted unit", logger.NewField("unit", msg.name))
+		w.status = fmt.Sprintf("Restarted %s", msg.name)
+		return w, w.fetchManual
+	}
+	return w, nil
+}
+
+// handleKey processes a key press while focused
+func (w *Widget) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if w.confirm != "" {
+		name := w.confirm
+		w.confirm = ""
+		if msg.String() == "y" {
+			return w.restart(name)
+		}
+		w.status = "Cancelled"
+		return nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if w.selected > 0 {
+			w.selected--
+		}
+	case "down", "j":
+		if w.selected < len(w.units)-1 {
+			w.selected++
+		}
+	case "r":
+		if w.selected < len(w.units) {
+			w.status = ""
+			w.lastError = nil
+			w.confirm = w.units[w.selected].Name
+		}
+	}
+	return nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	failed := 0
+	for _, u := range w.units {
+		if u.Failed() {
+			failed++
+		}
+	}
+	title := fmt.Sprintf("Services (%d)", len(w.units))
+	if failed > 0 {
+		title += lipgloss.NewStyle().Foreground(styles.Critical).Render(fmt.Sprintf(" %d failed", failed))
+	}
+	b.WriteString(styles.Title.Render(title))
+	b.WriteString("\n\n")
+
+	switch {
+	case len(w.names) == 0:
+		b.WriteString(subtle.Render("No units configured, set " + envUnits))
+	case w.units == nil && w.lastError == nil:
+		b.WriteString(subtle.Render("Loading..."))
+	default:
+		w.renderUnits(&b)
+	}
+
+	b.WriteString("\n")
+	switch {
+	case w.confirm != "":
+		warn := lipgloss.NewStyle().Foreground(styles.Secondary).Bold(true)
+		b.WriteString(warn.Render(fmt.Sprintf("Restart %s? y/n", w.confirm)))
+	case w.lastError != nil:
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+	case w.status != "":
+		b.WriteString(subtle.Render(w.status))
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("↑/↓: select • r: restart"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderUnits writes one line per unit with its state and time in that state
+func (w *Widget) renderUnits(b *strings.Builder) {
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true)
+
+	for i, u := range w.units {
+		state := lipgloss.NewStyle().Foreground(stateColor(u)).
+			Render(fmt.Sprintf("● %s (%s)", u.ActiveState, u.SubState))
+		line := fmt.Sprintf("%s %s", u.Name, state)
+		if !u.Since.IsZero() {
+			line += " " + subtle.Render("for "+formatSince(w.sampledAt.Sub(u.Since)))
+		}
+		if u.LoadState != "" && u.LoadState != "loaded" {
+			line += " " + subtle.Render(u.LoadState)
+		}
+
+		if i == w.selected && w.IsFocused() {
+			line = selectedStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// stateColor returns the display color for a unit's active state
+func stateColor(u Unit) lipgloss.Color {
+	switch u.ActiveState {
+	case "active", "reloading":
+		return styles.Primary
+	case "failed":
+		return styles.Critical
+	case "activating", "deactivating":
+		return styles.Warning
+	}
+	return styles.Subtle
+}
+
+// formatSince formats a duration using its two largest units
+func formatSince(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+// Message types for the systemd widget
+type unitsMsg struct {
+	units     []Unit
+	sampledAt time.Time
+	err       error
+	// manual is set for refreshes outside the interval, such as after a restart
+	manual bool
+}
+
+type refreshMsg struct{}
+
+type restartResultMsg struct {
+	name string
+	err  error
+}
+
+// Commands
+func (w *Widget) tick() tea.Cmd {
+	return tea.Tick(w.interval, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+func (w *Widget) fetchUnits() tea.Msg {
+	units, err := w.backend.Units(w.names)
+	return unitsMsg{units: units, sampledAt: time.Now(), err: err}
+}
+
+func (w *Widget) fetchManual() tea.Msg {
+	msg := w.fetchUnits().(unitsMsg)
+	msg.manual = true
+	return msg
+}
+
+func (w *Widget) restart(name string) tea.Cmd {
+	backend := w.backend
+	return func() tea.Msg {
+		return restartResultMsg{name: name, err: backend.Restart(name)}
+	}
+}
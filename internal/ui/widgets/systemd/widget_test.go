@@ -0,0 +1,157 @@
+package systemd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jonesrussell/dashboard/internal/testutil/testlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend serves fixed units and records restarts
+type fakeBackend struct {
+	units      []Unit
+	restarted  []string
+	restartErr error
+}
+
+func (f *fakeBackend) Units(names []string) ([]Unit, error) {
+	return f.units, nil
+}
+
+func (f *fakeBackend) Restart(name string) error {
+	if f.restartErr != nil {
+		return f.restartErr
+	}
+	f.restarted = append(f.restarted, name)
+	return nil
+}
+
+func TestParseShow(t *testing.T) {
+	out := `Id=nginx.service
+Description=A high performance web server
+LoadState=loaded
+ActiveState=active
+SubState=running
+StateChangeTimestamp=Mon 2024-01-15 10:30:00 UTC
+
+Id=backup.service
+Description=Nightly backup
+LoadState=loaded
+ActiveState=failed
+SubState=failed
+StateChangeTimestamp=
+
+Id=missing.service
+LoadState=not-found
+ActiveState=inactive
+SubState=dead
+`
+	units, err := parseShow(strings.NewReader(out))
+	require.NoError(t, err)
+	require.Len(t, units, 3)
+
+	assert.Equal(t, Unit{
+		Name:        "nginx.service",
+		Description: "A high performance web server",
+		LoadState:   "loaded",
+		ActiveState: "active",
+		SubState:    "running",
+		Since:       time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+	}, units[0])
+	assert.True(t, units[1].Failed())
+	assert.True(t, units[1].Since.IsZero())
+	assert.Equal(t, "not-found", units[2].LoadState)
+}
+
+func TestSystemctlBackend(t *testing.T) {
+	// A fake systemctl records its arguments and fails like an unauthorized restart
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(dir, "args") + "\necho 'Interactive authentication required.' >&2\nexit 1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "systemctl"), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+
+	err := NewSystemctlBackend(true).Restart("nginx.service")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Interactive authentication required.")
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	assert.Equal(t, "--user --no-ask-password restart -- nginx.service\n", string(args))
+}
+
+func TestSystemdWidget(t *testing.T) {
+	log, _ := testlogger.NewTestLogger(t, "systemd")
+	now := time.Now()
+	backend := &fakeBackend{units: []Unit{
+		{Name: "nginx.service", LoadState: "loaded", ActiveState: "active", SubState: "running", Since: now.Add(-2 * time.Hour)},
+		{Name: "backup.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed"},
+	}}
+
+	t.Run("env units", func(t *testing.T) {
+		t.Setenv(envUnits, "nginx.service, backup.service")
+		w := New(log)
+		assert.Equal(t, []string{"nginx.service", "backup.service"}, w.names)
+	})
+
+	t.Run("no units configured", func(t *testing.T) {
+		t.Setenv(envUnits, "")
+		w := New(log, WithBackend(backend))
+		w.SetSize(80, 20)
+		assert.Contains(t, w.View(), "No units configured")
+	})
+
+	w := New(log, WithBackend(backend), WithUnits("nginx.service", "backup.service"))
+	w.SetSize(80, 20)
+	w.Focus()
+
+	_, cmd := w.Update(w.fetchUnits())
+	assert.NotNil(t, cmd, "expected next tick")
+
+	view := w.View()
+	assert.Contains(t, view, "1 failed")
+	assert.Contains(t, view, "nginx.service ● active (running) for 2h 0m")
+	assert.Contains(t, view, "backup.service ● failed (failed)")
+
+	t.Run("restart requires confirmation", func(t *testing.T) {
+		w.Update(tea.KeyMsg{Type: tea.KeyDown})
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		assert.True(t, w.CapturingInput())
+		assert.Contains(t, w.View(), "Restart backup.service? y/n")
+
+		_, cmd := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+		assert.Nil(t, cmd)
+		assert.Empty(t, backend.restarted)
+
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		_, cmd = w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		require.NotNil(t, cmd)
+		_, cmd = w.Update(cmd())
+		require.NotNil(t, cmd, "expected refresh")
+		assert.Equal(t, []string{"backup.service"}, backend.restarted)
+		assert.Contains(t, w.View(), "Restarted backup.service")
+		_, cmd = w.Update(cmd())
+		assert.Nil(t, cmd, "the interval refresh is already scheduled")
+	})
+
+	t.Run("restart failure", func(t *testing.T) {
+		backend.restartErr = errors.New("access denied")
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		_, cmd := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		w.Update(cmd())
+		assert.Contains(t, w.View(), "access denied")
+	})
+}
+
+func TestFormatSince(t *testing.T) {
+	assert.Equal(t, "0s", formatSince(-time.Second))
+	assert.Equal(t, "42s", formatSince(42*time.Second))
+	assert.Equal(t, "5m", formatSince(5*time.Minute+10*time.Second))
+	assert.Equal(t, "3d 4h", formatSince(76*time.Hour))
+}
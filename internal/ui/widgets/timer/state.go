@@ -0,0 +1,179 @@
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Phase is a stage of the work/break cycle
+type Phase int
+
+// Timer phases
+const (
+	Work Phase = iota
+	ShortBreak
+	LongBreak
+)
+
+// String returns the display name of the phase
+func (p Phase) String() string {
+	switch p {
+	case ShortBreak:
+		return "Break"
+	case LongBreak:
+		return "Long break"
+	}
+	return "Work"
+}
+
+// Durations configures the length of each phase
+type Durations struct {
+	Work       time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+	// LongBreakEvery is the number of work phases between long breaks
+	LongBreakEvery int
+}
+
+// DefaultDurations are the classic pomodoro timings
+var DefaultDurations = Durations{
+	Work:           25 * time.Minute,
+	ShortBreak:     5 * time.Minute,
+	LongBreak:      15 * time.Minute,
+	LongBreakEvery: 4,
+}
+
+// of returns the duration of phase p
+func (d Durations) of(p Phase) time.Duration {
+	switch p {
+	case ShortBreak:
+		return d.ShortBreak
+	case LongBreak:
+		return d.LongBreak
+	}
+	return d.Work
+}
+
+// state is the persisted timer state. While running only EndsAt is
+// meaningful, so a restart resumes against the wall clock.
+type state struct {
+	Phase     Phase         `json:"phase"`
+	Completed int           `json:"completed"`
+	Running   bool          `json:"running"`
+	Remaining time.Duration `json:"remaining"`
+	EndsAt    time.Time     `json:"ends_at,omitempty"`
+}
+
+// remaining returns the time left in the phase at now
+func (s *state) remaining(now time.Time) time.Duration {
+	if !s.Running {
+		return s.Remaining
+	}
+	return max(s.EndsAt.Sub(now), 0)
+}
+
+// start resumes the countdown
+func (s *state) start(now time.Time) {
+	if s.Running {
+		return
+	}
+	s.Running = true
+	s.EndsAt = now.Add(s.Remaining)
+}
+
+// pause stops the countdown, keeping the time left
+func (s *state) pause(now time.Time) {
+	if !s.Running {
+		return
+	}
+	s.Remaining = s.remaining(now)
+	s.Running = false
+	s.EndsAt = time.Time{}
+}
+
+// reset restarts the current phase, paused
+func (s *state) reset(d Durations) {
+	s.Running = false
+	s.EndsAt = time.Time{}
+	s.Remaining = d.of(s.Phase)
+}
+
+// advance moves to the next phase, paused at its full duration
+func (s *state) advance(d Durations) {
+	if s.Phase == Work {
+		s.Completed++
+		s.Phase = ShortBreak
+		if d.LongBreakEvery > 0 && s.Completed%d.LongBreakEvery == 0 {
+			s.Phase = LongBreak
+		}
+	} else {
+		s.Phase = Work
+	}
+	s.reset(d)
+}
+
+// loadState reads the state file, returning a fresh state if it does not exist
+func loadState(path string, d Durations) (state, error) {
+	fresh := state{Phase: Work, Remaining: d.Work}
+	if path == "" {
+		return fresh, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fresh, nil
+	}
+	if err != nil {
+		return fresh, fmt.Errorf("failed to read timer state: %w", err)
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fresh, fmt.Errorf("failed to parse timer state: %w", err)
+	}
+	if s.Phase < Work || s.Phase > LongBreak {
+		return fresh, nil
+	}
+	// Durations may have been shortened since the state was saved
+	if !s.Running && (s.Remaining <= 0 || s.Remaining > d.of(s.Phase)) {
+		s.Remaining = d.of(s.Phase)
+	}
+	return s, nil
+}
+
+// saveState writes the state file atomically
+func saveState(path string, s state) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode timer state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write timer state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write timer state: %w", err)
+	}
+	return nil
+}
+
+// defaultStatePath returns the state file in the user cache directory
+func defaultStatePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "dashboard", "timer.json")
+}
@@ -0,0 +1,263 @@
+// Package timer provides a pomodoro-style work/break timer widget
+package timer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jonesrussell/dashboard/internal/ui/components"
+	"github.com/jonesrussell/dashboard/internal/ui/styles"
+)
+
+// Default configuration
+const (
+	envWork      = "DASHBOARD_TIMER_WORK"
+	envBreak     = "DASHBOARD_TIMER_BREAK"
+	envLongBreak = "DASHBOARD_TIMER_LONG_BREAK"
+	envBell      = "DASHBOARD_TIMER_BELL"
+	envState     = "DASHBOARD_TIMER_STATE"
+
+	// bell is the terminal bell character
+	bell = "\a"
+	// chromeWidth is the border and padding around the widget content
+	chromeWidth = 4
+)
+
+// Widget represents the timer widget
+type Widget struct {
+	components.BaseWidget
+	durations Durations
+	statePath string
+	bell      io.Writer
+	now       func() time.Time
+
+	state state
+	// tickID identifies the current tick chain so ticks from before a
+	// pause are dropped
+	tickID    int
+	lastError error
+}
+
+// Option allows configuring the widget
+type Option func(*Widget)
+
+// WithDurations sets the phase durations
+func WithDurations(d Durations) Option {
+	return func(w *Widget) {
+		w.durations = d
+	}
+}
+
+// WithStatePath sets the file the timer state is persisted to; an empty
+// path disables persistence
+func WithStatePath(path string) Option {
+	return func(w *Widget) {
+		w.statePath = path
+	}
+}
+
+// WithBell sets where the bell is written at phase changes; nil disables it
+func WithBell(out io.Writer) Option {
+	return func(w *Widget) {
+		w.bell = out
+	}
+}
+
+// WithNow sets the time source, mainly for tests
+func WithNow(now func() time.Time) Option {
+	return func(w *Widget) {
+		w.now = now
+	}
+}
+
+// New creates a new timer widget. Durations default to DASHBOARD_TIMER_WORK,
+// DASHBOARD_TIMER_BREAK and DASHBOARD_TIMER_LONG_BREAK (e.g. "50m"), and state
+// is kept in DASHBOARD_TIMER_STATE or the user cache directory.
+func New(opts ...Option) *Widget {
+	durations := DefaultDurations
+	durations.Work = envDuration(envWork, durations.Work)
+	durations.ShortBreak = envDuration(envBreak, durations.ShortBreak)
+	durations.LongBreak = envDuration(envLongBreak, durations.LongBreak)
+
+	statePath := os.Getenv(envState)
+	if statePath == "" {
+		statePath = defaultStatePath()
+	}
+
+	w := &Widget{
+		durations: durations,
+		statePath: statePath,
+		bell:      os.Stdout,
+		now:       time.Now,
+	}
+	if enabled, err := strconv.ParseBool(os.Getenv(envBell)); err == nil && !enabled {
+		w.bell = nil
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.state, w.lastError = loadState(w.statePath, w.durations)
+	// A phase that ended while the dashboard was closed is complete
+	if w.state.Running && w.state.remaining(w.now()) <= 0 {
+		w.state.advance(w.durations)
+		w.save()
+	}
+
+	return w
+}
+
+// Init implements components.Widget
+func (w *Widget) Init() tea.Cmd {
+	if w.state.Running {
+		return w.tick()
+	}
+	return nil
+}
+
+// Update implements components.Widget
+func (w *Widget) Update(msg tea.Msg) (components.Widget, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !w.IsFocused() {
+			return w, nil
+		}
+		return w, w.handleKey(msg)
+	case tickMsg:
+		if msg.id != w.tickID || !w.state.Running {
+			return w, nil
+		}
+		if w.state.remaining(w.now()) <= 0 {
+			w.state.advance(w.durations)
+			w.ringBell()
+			w.save()
+			return w, nil
+		}
+		return w, w.tick()
+	}
+	return w, nil
+}
+
+// handleKey processes a key press while focused
+func (w *Widget) handleKey(msg tea.KeyMsg) tea.Cmd {
+	now := w.now()
+	switch msg.String() {
+	case " ", "s":
+		if w.state.Running {
+			w.state.pause(now)
+			w.save()
+			return nil
+		}
+		w.state.start(now)
+		w.save()
+		return w.tick()
+	case "r":
+		w.state.reset(w.durations)
+		w.save()
+	case "n":
+		w.state.advance(w.durations)
+		w.save()
+	}
+	return nil
+}
+
+// View implements components.Widget
+func (w *Widget) View() string {
+	width, height := w.GetDimensions()
+	var b strings.Builder
+	b.Grow(width * height)
+
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	color := styles.Primary
+	if w.state.Phase != Work {
+		color = styles.Secondary
+	}
+
+	b.WriteString(styles.Title.Render(fmt.Sprintf("Timer · %s", w.state.Phase)))
+	b.WriteString(subtle.Render(fmt.Sprintf(" (%d done)", w.state.Completed)))
+	b.WriteString("\n\n")
+
+	remaining := w.state.remaining(w.now())
+	b.WriteString(lipgloss.NewStyle().Foreground(color).Bold(true).Render(formatRemaining(remaining)))
+	if w.state.Running {
+		b.WriteString(" running")
+	} else {
+		b.WriteString(subtle.Render(" paused"))
+	}
+	b.WriteString("\n")
+
+	total := w.durations.of(w.state.Phase)
+	progress := 0.0
+	if total > 0 {
+		progress = float64(total-remaining) / float64(total) * 100
+	}
+	b.WriteString(components.UsageBar(progress, max(width-chromeWidth, 10), color))
+	b.WriteString("\n")
+
+	if w.lastError != nil {
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+		b.WriteString(errorStyle.Render(w.lastError.Error()))
+		b.WriteString("\n")
+	}
+
+	// Help text
+	if w.IsFocused() {
+		b.WriteString("\n")
+		b.WriteString(subtle.Render("space: start/pause • r: reset • n: next phase"))
+	}
+
+	return w.GetStyle().Width(width).Height(height).Render(b.String())
+}
+
+// ringBell signals a phase change
+func (w *Widget) ringBell() {
+	if w.bell != nil {
+		if _, err := io.WriteString(w.bell, bell); err != nil {
+			w.lastError = fmt.Errorf("failed to ring bell: %w", err)
+		}
+	}
+}
+
+// save persists the state, surfacing failures in the view
+func (w *Widget) save() {
+	w.lastError = saveState(w.statePath, w.state)
+}
+
+// formatRemaining formats a countdown as MM:SS, rounding up so the display
+// reaches 00:00 only when the phase ends
+func formatRemaining(d time.Duration) string {
+	secs := int((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// envDuration reads a duration from key, falling back to def if unset or invalid
+func envDuration(key string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+// Message types for the timer widget
+type tickMsg struct {
+	id int
+}
+
+// Commands
+
+// tick starts a new tick chain aligned to the system clock's seconds
+func (w *Widget) tick() tea.Cmd {
+	w.tickID++
+	id := w.tickID
+	return tea.Every(time.Second, func(time.Time) tea.Msg {
+		return tickMsg{id: id}
+	})
+}
@@ -0,0 +1,143 @@
+package timer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDurations = Durations{
+	Work:           10 * time.Minute,
+	ShortBreak:     2 * time.Minute,
+	LongBreak:      5 * time.Minute,
+	LongBreakEvery: 2,
+}
+
+func press(w *Widget, key string) tea.Cmd {
+	_, cmd := w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return cmd
+}
+
+func TestState(t *testing.T) {
+	s := state{Phase: Work, Remaining: testDurations.Work}
+	start := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	s.start(start)
+	assert.Equal(t, 7*time.Minute, s.remaining(start.Add(3*time.Minute)))
+	s.pause(start.Add(3 * time.Minute))
+	assert.Equal(t, 7*time.Minute, s.remaining(start.Add(time.Hour)), "paused time does not count")
+
+	s.advance(testDurations)
+	assert.Equal(t, ShortBreak, s.Phase)
+	s.advance(testDurations)
+	assert.Equal(t, Work, s.Phase)
+	s.advance(testDurations)
+	assert.Equal(t, LongBreak, s.Phase, "every second work phase")
+	assert.Equal(t, 2, s.Completed)
+	assert.Equal(t, testDurations.LongBreak, s.Remaining)
+}
+
+func TestTimerWidget(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state", "timer.json")
+	now := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	var bellOut bytes.Buffer
+
+	newWidget := func() *Widget {
+		w := New(
+			WithDurations(testDurations),
+			WithStatePath(statePath),
+			WithBell(&bellOut),
+			WithNow(func() time.Time { return now }),
+		)
+		w.SetSize(40, 20)
+		w.Focus()
+		return w
+	}
+
+	t.Run("env configuration", func(t *testing.T) {
+		t.Setenv(envWork, "50m")
+		t.Setenv(envBreak, "invalid")
+		t.Setenv(envBell, "false")
+		t.Setenv(envState, filepath.Join(t.TempDir(), "timer.json"))
+		w := New()
+		assert.Equal(t, 50*time.Minute, w.durations.Work)
+		assert.Equal(t, DefaultDurations.ShortBreak, w.durations.ShortBreak)
+		assert.Nil(t, w.bell)
+	})
+
+	w := newWidget()
+	assert.Nil(t, w.Init(), "paused timer does not tick")
+	view := w.View()
+	assert.Contains(t, view, "Timer · Work")
+	assert.Contains(t, view, "10:00 paused")
+
+	require.NotNil(t, press(w, " "), "start ticks")
+	now = now.Add(4*time.Minute + 30*time.Second)
+	assert.Contains(t, w.View(), "05:30 running")
+
+	t.Run("stale ticks are ignored", func(t *testing.T) {
+		staleID := w.tickID
+		press(w, " ")
+		press(w, " ")
+		_, cmd := w.Update(tickMsg{id: staleID})
+		assert.Nil(t, cmd)
+		_, cmd = w.Update(tickMsg{id: w.tickID})
+		assert.NotNil(t, cmd)
+	})
+
+	t.Run("state survives restart", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		restarted := newWidget()
+		assert.NotNil(t, restarted.Init(), "running timer resumes ticking")
+		assert.Contains(t, restarted.View(), "04:30 running")
+	})
+
+	t.Run("phase change rings bell", func(t *testing.T) {
+		now = now.Add(10 * time.Minute)
+		_, cmd := w.Update(tickMsg{id: w.tickID})
+		assert.Nil(t, cmd, "ticking stops at the phase change")
+		assert.Equal(t, bell, bellOut.String())
+
+		view := w.View()
+		assert.Contains(t, view, "Timer · Break")
+		assert.Contains(t, view, "(1 done)")
+		assert.Contains(t, view, "02:00 paused")
+	})
+
+	t.Run("reset and skip", func(t *testing.T) {
+		press(w, " ")
+		now = now.Add(30 * time.Second)
+		press(w, "r")
+		assert.Contains(t, w.View(), "02:00 paused")
+
+		press(w, "n")
+		assert.Contains(t, w.View(), "Timer · Work")
+	})
+
+	t.Run("phase completed while closed", func(t *testing.T) {
+		press(w, " ")
+		now = now.Add(time.Hour)
+		restarted := newWidget()
+		assert.Contains(t, restarted.View(), "Timer · Long break")
+		assert.Contains(t, restarted.View(), "05:00 paused")
+	})
+
+	t.Run("corrupt state file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(statePath, []byte("{"), 0o644))
+		w := newWidget()
+		assert.Contains(t, w.View(), "failed to parse timer state")
+		assert.Contains(t, w.View(), "10:00 paused")
+	})
+}
+
+func TestFormatRemaining(t *testing.T) {
+	assert.Equal(t, "25:00", formatRemaining(25*time.Minute))
+	assert.Equal(t, "00:01", formatRemaining(100*time.Millisecond))
+	assert.Equal(t, "00:00", formatRemaining(0))
+}